@@ -1,6 +1,7 @@
 package goutils
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -13,11 +14,90 @@ import (
 	"github.com/gabriel-vasile/mimetype"
 )
 
+// Secure path errors.
+var (
+	ErrPathEscapesRoot = errors.New("path escapes root")
+	ErrInvalidPath     = errors.New("invalid path")
+)
+
+// windowsVolumeRx matches windows drive (e.g. C: or C:\) and UNC (e.g. \\server\share) path prefixes.
+var windowsVolumeRx = regexp.MustCompile(`^([a-zA-Z]:|[\\/]{2})`)
+
 // NormalizePath join and normalize file path.
 func NormalizePath(path ...string) string {
 	return filepath.ToSlash(filepath.Clean(filepath.Join(path...)))
 }
 
+// SecureJoin join untrusted path parts to root and returns path inside root.
+// Both slash and backslash treated as separator in untrusted parts and ".." resolved.
+// Existing symlinks resolved and must point inside root.
+// Returns ErrPathEscapesRoot if result is outside of root
+// and ErrInvalidPath for parts with NUL byte or windows drive and UNC forms.
+// Symlinks created after join are not checked.
+//
+// code block:
+//
+//	SecureJoin("/var/www/uploads", "avatars/../docs/a.pdf")
+//	SecureJoin("/var/www/uploads", "../../etc/passwd")
+//
+// output:
+//
+//	/var/www/uploads/docs/a.pdf
+//	ErrPathEscapesRoot
+func SecureJoin(root string, untrusted ...string) (string, error) {
+	if root == "" {
+		return "", fmt.Errorf("%w: empty root", ErrInvalidPath)
+	}
+
+	parts := make([]string, 0, len(untrusted)+1)
+	parts = append(parts, root)
+	for _, part := range untrusted {
+		if strings.ContainsRune(part, 0) || windowsVolumeRx.MatchString(part) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPath, part)
+		}
+		parts = append(parts, filepath.FromSlash(strings.ReplaceAll(part, "\\", "/")))
+	}
+
+	root = filepath.Clean(root)
+	joined := filepath.Join(parts...)
+	if !isSubPath(root, joined) {
+		return "", ErrPathEscapesRoot
+	}
+
+	// Resolve symlinks of longest existing path
+	realRoot, err := filepath.EvalSymlinks(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return joined, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	existing := joined
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		existing = filepath.Dir(existing)
+	}
+
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	if !isSubPath(realRoot, resolved) {
+		return "", ErrPathEscapesRoot
+	}
+	return joined, nil
+}
+
+// isSubPath check if cleaned path is equal to or inside cleaned root.
+func isSubPath(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
 // CreateDirectory create nested directory.
 func CreateDirectory(path string) error {
 	return os.MkdirAll(path, os.ModePerm)
@@ -87,25 +167,9 @@ func FileExists(path string) (bool, error) {
 // FindFile search directory for file with pattern and returns first file.
 func FindFile(dir string, pattern string) *string {
 	var result string
-
-	// Create regex
-	rx, err := regexp.Compile(pattern)
-	if err != nil {
-		return nil
-	}
-
-	// Search for file
-	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() && rx.MatchString(info.Name()) {
-			result = path
-			return filepath.SkipAll
-		}
-
-		return nil
+	err := walkMatchedFiles(dir, pattern, func(path string, _ os.FileInfo) error {
+		result = path
+		return filepath.SkipAll
 	})
 
 	// Handle result
@@ -118,31 +182,38 @@ func FindFile(dir string, pattern string) *string {
 // FindFiles search directory for files with pattern.
 func FindFiles(dir string, pattern string) []string {
 	var result []string
+	err := walkMatchedFiles(dir, pattern, func(path string, _ os.FileInfo) error {
+		result = append(result, path)
+		return nil
+	})
 
+	// Handle result
+	if err != nil || len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// walkMatchedFiles walk directory and call fn for files with name matching pattern.
+func walkMatchedFiles(dir, pattern string, fn func(path string, info os.FileInfo) error) error {
 	// Create regex
 	rx, err := regexp.Compile(pattern)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	// Search for file
-	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if !info.IsDir() && rx.MatchString(info.Name()) {
-			result = append(result, path)
+			return fn(path, info)
 		}
 
 		return nil
 	})
-
-	// Handle result
-	if err != nil || len(result) == 0 {
-		return nil
-	}
-	return result
 }
 
 // GetMime returns file mime info from content
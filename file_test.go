@@ -1,6 +1,7 @@
 package goutils_test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,6 +18,54 @@ func TestNormalizePath(t *testing.T) {
 	}
 }
 
+func TestSecureJoin(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "docs"), os.ModePerm)
+	os.Symlink(filepath.Join(root, "docs"), filepath.Join(root, "inner"))
+	os.Symlink(outside, filepath.Join(root, "outer"))
+	os.Symlink("../../", filepath.Join(root, "docs", "up"))
+
+	tests := []struct {
+		parts    []string
+		expected string
+		err      error
+	}{
+		{[]string{"docs/a.pdf"}, filepath.Join(root, "docs", "a.pdf"), nil},
+		{[]string{"docs", "..", "b.pdf"}, filepath.Join(root, "b.pdf"), nil},
+		{[]string{"/etc/passwd"}, filepath.Join(root, "etc", "passwd"), nil},
+		{[]string{`docs\new\c.pdf`}, filepath.Join(root, "docs", "new", "c.pdf"), nil},
+		{[]string{"inner/a.pdf"}, filepath.Join(root, "inner", "a.pdf"), nil},
+		{[]string{""}, root, nil},
+		{[]string{"../etc/passwd"}, "", goutils.ErrPathEscapesRoot},
+		{[]string{"docs/../../etc/passwd"}, "", goutils.ErrPathEscapesRoot},
+		{[]string{`..\..\etc\passwd`}, "", goutils.ErrPathEscapesRoot},
+		{[]string{"outer/secret.txt"}, "", goutils.ErrPathEscapesRoot},
+		{[]string{"outer"}, "", goutils.ErrPathEscapesRoot},
+		{[]string{"docs/up/new.txt"}, "", goutils.ErrPathEscapesRoot},
+		{[]string{"a\x00.txt"}, "", goutils.ErrInvalidPath},
+		{[]string{`C:\Windows`}, "", goutils.ErrInvalidPath},
+		{[]string{"c:file.txt"}, "", goutils.ErrInvalidPath},
+		{[]string{`\\server\share`}, "", goutils.ErrInvalidPath},
+		{[]string{"//server/share"}, "", goutils.ErrInvalidPath},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.SecureJoin(root, test.parts...)
+		if result != test.expected || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("SecureJoin(root, %q) = %q, %v; want %q, %v", test.parts, result, err, test.expected, test.err)
+		}
+	}
+
+	// Not existing root checked lexically
+	if result, err := goutils.SecureJoin("/not/exists", "a/../b"); err != nil || result != filepath.Join("/not/exists", "b") {
+		t.Errorf("SecureJoin() on missing root = %q, %v", result, err)
+	}
+	if _, err := goutils.SecureJoin("", "a"); !errors.Is(err, goutils.ErrInvalidPath) {
+		t.Errorf("SecureJoin() with empty root error = %v; want %v", err, goutils.ErrInvalidPath)
+	}
+}
+
 func TestCreateDirectory(t *testing.T) {
 	dir := "testdir/1/2"
 	err := goutils.CreateDirectory(dir)
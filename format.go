@@ -0,0 +1,115 @@
+package goutils
+
+import (
+	"math"
+	"sync"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// compactUnits contains thousand, million, billion and trillion units of language.
+var compactUnits = map[string][]string{
+	"en": {"K", "M", "B", "T"},
+	"fa": {" هزار", " میلیون", " میلیارد", " تریلیون"},
+	"ar": {" ألف", " مليون", " مليار", " تريليون"},
+}
+
+// numberFormatters caches formatters by language tag.
+var numberFormatters sync.Map
+
+// NumberFormatter format numbers with localized digits and separators.
+// NumberFormatter is safe for concurrent use.
+type NumberFormatter struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+// NewNumberFormatter returns cached number formatter for language.
+func NewNumberFormatter(tag language.Tag) *NumberFormatter {
+	if f, ok := numberFormatters.Load(tag); ok {
+		return f.(*NumberFormatter)
+	}
+
+	f, _ := numberFormatters.LoadOrStore(tag, &NumberFormatter{
+		tag:     tag,
+		printer: message.NewPrinter(tag),
+	})
+	return f.(*NumberFormatter)
+}
+
+// Language returns formatter language tag.
+func (f *NumberFormatter) Language() language.Tag {
+	return f.tag
+}
+
+// Sprintf format layout with localized numbers.
+func (f *NumberFormatter) Sprintf(layout string, v ...any) string {
+	return f.printer.Sprintf(layout, v...)
+}
+
+// Number format number with localized digits and separators.
+func (f *NumberFormatter) Number(v any) string {
+	return f.printer.Sprint(number.Decimal(v))
+}
+
+// Decimal format number with maximum decimals fraction digits.
+func (f *NumberFormatter) Decimal(v any, decimals int) string {
+	return f.printer.Sprint(number.Decimal(v, number.MaxFractionDigits(decimals)))
+}
+
+// Percent format ratio as percent (e.g. 0.25 formatted as 25%).
+func (f *NumberFormatter) Percent(v any, decimals int) string {
+	return f.printer.Sprint(number.Percent(v, number.MaxFractionDigits(decimals)))
+}
+
+// Currency format number followed by currency unit.
+//
+// code block:
+//
+//	NewNumberFormatter(language.Persian).Currency(100000, "تومان")
+//
+// output:
+//
+//	۱۰۰٬۰۰۰ تومان
+func (f *NumberFormatter) Currency(v any, unit string) string {
+	return f.Number(v) + " " + unit
+}
+
+// Compact format number in short form (e.g. 1.2K, 3.4M).
+// Languages without compact units fallback to english units.
+func (f *NumberFormatter) Compact(v float64) string {
+	base, _ := f.tag.Base()
+	units, ok := compactUnits[base.String()]
+	if !ok {
+		units = compactUnits["en"]
+	}
+
+	// Compare rounded value to prevent results like 1000K
+	round := func(n float64) float64 { return math.Round(n*10) / 10 }
+	unit, scaled := -1, v
+	for unit+1 < len(units) && math.Abs(round(scaled)) >= 1000 {
+		unit++
+		scaled = v / math.Pow(1000, float64(unit+1))
+	}
+
+	res := f.Decimal(round(scaled), 1)
+	if unit >= 0 {
+		res += units[unit]
+	}
+	return res
+}
+
+// FormatNumberLocale format number with localized digits and separators.
+//
+// code block:
+//
+//	FormatNumberLocale(language.Persian, "%d تومان", 100000)
+//
+// output:
+//
+//	۱۰۰٬۰۰۰ تومان
+func FormatNumberLocale(tag language.Tag, layout string, v ...any) string {
+	return NewNumberFormatter(tag).Sprintf(layout, v...)
+}
@@ -0,0 +1,53 @@
+package goutils_test
+
+import (
+	"testing"
+
+	"github.com/mekramy/goutils"
+	"golang.org/x/text/language"
+)
+
+func TestNewNumberFormatter(t *testing.T) {
+	a := goutils.NewNumberFormatter(language.Persian)
+	b := goutils.NewNumberFormatter(language.Persian)
+	if a != b {
+		t.Errorf("NewNumberFormatter should return cached formatter")
+	}
+}
+
+func TestNumberFormatter(t *testing.T) {
+	fa := goutils.NewNumberFormatter(language.Persian)
+	en := goutils.NewNumberFormatter(language.English)
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"Number", fa.Number(1234567), "۱٬۲۳۴٬۵۶۷"},
+		{"Decimal", fa.Decimal(1234.5678, 2), "۱٬۲۳۴٫۵۷"},
+		{"Percent", en.Percent(0.256, 0), "26%"},
+		{"Currency", fa.Currency(100000, "تومان"), "۱۰۰٬۰۰۰ تومان"},
+		{"Compact", en.Compact(1234), "1.2K"},
+		{"Compact", en.Compact(-3400000), "-3.4M"},
+		{"Compact", en.Compact(999960), "1M"},
+		{"Compact", en.Compact(512), "512"},
+		{"Compact", fa.Compact(2500000000), "۲٫۵ میلیارد"},
+		{"Compact", goutils.NewNumberFormatter(language.German).Compact(1500), "1,5K"},
+	}
+
+	for _, test := range tests {
+		if test.result != test.expected {
+			t.Errorf("%s() = %q; want %q", test.name, test.result, test.expected)
+		}
+	}
+}
+
+func TestFormatNumberLocale(t *testing.T) {
+	layout := "%d تومان"
+	value := 100000
+	expected := "۱۰۰٬۰۰۰ تومان"
+	result := goutils.FormatNumberLocale(language.Persian, layout, value)
+	if result != expected {
+		t.Errorf("FormatNumberLocale(%q, %d) = %q; want %q", layout, value, result, expected)
+	}
+}
@@ -6,11 +6,11 @@ require (
 	github.com/gabriel-vasile/mimetype v1.4.8
 	github.com/microcosm-cc/bluemonday v1.0.27
 	golang.org/x/exp v0.0.0-20250128182459-e0ece0dbea4c
+	golang.org/x/net v0.33.0
 	golang.org/x/text v0.21.0
 )
 
 require (
 	github.com/aymerick/douceur v0.2.0 // indirect
 	github.com/gorilla/css v1.0.1 // indirect
-	golang.org/x/net v0.33.0 // indirect
 )
@@ -0,0 +1,434 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// jalaliBreaks contains jalali years with leap cycle breaks.
+var jalaliBreaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+// JalaliMonths contains persian name of jalali months.
+var JalaliMonths = []string{
+	"فروردین", "اردیبهشت", "خرداد", "تیر", "مرداد", "شهریور",
+	"مهر", "آبان", "آذر", "دی", "بهمن", "اسفند",
+}
+
+// JalaliMonthsFinglish contains finglish name of jalali months.
+var JalaliMonthsFinglish = []string{
+	"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar",
+	"Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand",
+}
+
+// JalaliWeekdays contains persian name of weekdays indexed by time.Weekday.
+var JalaliWeekdays = []string{
+	"یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه", "شنبه",
+}
+
+// JalaliWeekdaysFinglish contains finglish name of weekdays indexed by time.Weekday.
+var JalaliWeekdaysFinglish = []string{
+	"Yekshanbe", "Doshanbe", "Seshanbe", "Chaharshanbe", "Panjshanbe", "Jome", "Shanbe",
+}
+
+// Jalali represents date and time in jalali (solar hijri) calendar.
+// Supported years range is -61 to 3177.
+type Jalali struct {
+	t     time.Time
+	year  int
+	month int
+	day   int
+}
+
+// NewJalali convert time to jalali date.
+// Times out of supported range (e.g. time.Time{}) clamped to
+// start of -61/01/01 or end of 3177/12/29 in time location.
+func NewJalali(t time.Time) Jalali {
+	gy, gm, gd := t.Date()
+	jdn := gregorianToJDN(gy, int(gm), gd)
+
+	first := jalaliBreaks[0]
+	last := jalaliBreaks[len(jalaliBreaks)-1] - 1
+	if minJDN := jalaliToJDN(first, 1, 1); jdn < minJDN {
+		gy, gm, gd := jdnToGregorian(minJDN)
+		t = time.Date(gy, time.Month(gm), gd, 0, 0, 0, 0, t.Location())
+		jdn = minJDN
+	} else if maxJDN := jalaliToJDN(last, 12, JalaliMonthDays(last, 12)); jdn > maxJDN {
+		gy, gm, gd := jdnToGregorian(maxJDN)
+		t = time.Date(gy, time.Month(gm), gd, 23, 59, 59, 999999999, t.Location())
+		jdn = maxJDN
+	}
+
+	jy, jm, jd := jdnToJalali(jdn)
+	return Jalali{t: t, year: jy, month: jm, day: jd}
+}
+
+// JalaliNow returns current jalali date in local time.
+func JalaliNow() Jalali {
+	return NewJalali(time.Now())
+}
+
+// JalaliDate returns jalali date from components or error if date is invalid.
+func JalaliDate(year, month, day, hour, min, sec, nsec int, loc *time.Location) (Jalali, error) {
+	if year < jalaliBreaks[0] || year >= jalaliBreaks[len(jalaliBreaks)-1] {
+		return Jalali{}, fmt.Errorf("jalali year %d out of range", year)
+	}
+	if month < 1 || month > 12 {
+		return Jalali{}, fmt.Errorf("invalid jalali month %d", month)
+	}
+	if day < 1 || day > JalaliMonthDays(year, month) {
+		return Jalali{}, fmt.Errorf("invalid jalali day %d", day)
+	}
+
+	gy, gm, gd := jdnToGregorian(jalaliToJDN(year, month, day))
+	t := time.Date(gy, time.Month(gm), gd, hour, min, sec, nsec, loc)
+	return Jalali{t: t, year: year, month: month, day: day}, nil
+}
+
+// IsJalaliLeap check if jalali year is leap year.
+func IsJalaliLeap(year int) bool {
+	leap, _, _ := jalaliCal(year)
+	return leap == 0
+}
+
+// JalaliMonthDays returns number of days in jalali month.
+func JalaliMonthDays(year, month int) int {
+	switch {
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	case IsJalaliLeap(year):
+		return 30
+	default:
+		return 29
+	}
+}
+
+// Time returns gregorian time.
+func (j Jalali) Time() time.Time {
+	return j.t
+}
+
+// Year returns jalali year.
+func (j Jalali) Year() int {
+	return j.year
+}
+
+// Month returns jalali month (1-12).
+func (j Jalali) Month() int {
+	return j.month
+}
+
+// Day returns jalali day of month.
+func (j Jalali) Day() int {
+	return j.day
+}
+
+// YearDay returns day of year (1-366).
+func (j Jalali) YearDay() int {
+	if j.month <= 7 {
+		return (j.month-1)*31 + j.day
+	}
+	return 186 + (j.month-7)*30 + j.day
+}
+
+// Weekday returns day of week.
+func (j Jalali) Weekday() time.Weekday {
+	return j.t.Weekday()
+}
+
+// IsLeap check if jalali year is leap year.
+func (j Jalali) IsLeap() bool {
+	return IsJalaliLeap(j.year)
+}
+
+// MonthName returns persian month name or empty string for zero value.
+func (j Jalali) MonthName() string {
+	if j.month < 1 {
+		return ""
+	}
+	return JalaliMonths[j.month-1]
+}
+
+// MonthNameFinglish returns finglish month name or empty string for zero value.
+func (j Jalali) MonthNameFinglish() string {
+	if j.month < 1 {
+		return ""
+	}
+	return JalaliMonthsFinglish[j.month-1]
+}
+
+// WeekdayName returns persian weekday name.
+func (j Jalali) WeekdayName() string {
+	return JalaliWeekdays[j.Weekday()]
+}
+
+// WeekdayNameFinglish returns finglish weekday name.
+func (j Jalali) WeekdayNameFinglish() string {
+	return JalaliWeekdaysFinglish[j.Weekday()]
+}
+
+// AddDays returns date with n days added.
+func (j Jalali) AddDays(n int) Jalali {
+	return NewJalali(j.t.AddDate(0, 0, n))
+}
+
+// AddMonths returns date with n months added.
+// Day clamped to last day of month (e.g. 1403/06/31 + 1 month = 1403/07/30).
+func (j Jalali) AddMonths(n int) Jalali {
+	months := j.year*12 + j.month - 1 + n
+	year, month := months/12, months%12+1
+	if month <= 0 {
+		year, month = year-1, month+12
+	}
+	return j.withDate(year, month, j.day)
+}
+
+// AddYears returns date with n years added.
+// Day clamped to last day of month (e.g. 1403/12/30 + 1 year = 1404/12/29).
+func (j Jalali) AddYears(n int) Jalali {
+	return j.withDate(j.year+n, j.month, j.day)
+}
+
+// String returns date in YYYY/MM/DD format.
+func (j Jalali) String() string {
+	return j.Format("%Y/%m/%d")
+}
+
+// Format returns date formatted by strftime-like layout.
+//
+// Supported verbs:
+//
+//	%Y year (1403), %y two digit year (03), %m month (01), %d day (01),
+//	%B persian month name, %b finglish month name,
+//	%A persian weekday name, %a finglish weekday name,
+//	%j day of year (001), %H hour (00-23), %M minute, %S second, %% percent sign.
+func (j Jalali) Format(layout string) string {
+	var res strings.Builder
+	runes := []rune(layout)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' || i+1 >= len(runes) {
+			res.WriteRune(runes[i])
+			continue
+		}
+
+		i++
+		switch runes[i] {
+		case 'Y':
+			res.WriteString(fmt.Sprintf("%04d", j.year))
+		case 'y':
+			res.WriteString(fmt.Sprintf("%02d", j.year%100))
+		case 'm':
+			res.WriteString(fmt.Sprintf("%02d", j.month))
+		case 'd':
+			res.WriteString(fmt.Sprintf("%02d", j.day))
+		case 'B':
+			res.WriteString(j.MonthName())
+		case 'b':
+			res.WriteString(j.MonthNameFinglish())
+		case 'A':
+			res.WriteString(j.WeekdayName())
+		case 'a':
+			res.WriteString(j.WeekdayNameFinglish())
+		case 'j':
+			res.WriteString(fmt.Sprintf("%03d", j.YearDay()))
+		case 'H':
+			res.WriteString(fmt.Sprintf("%02d", j.t.Hour()))
+		case 'M':
+			res.WriteString(fmt.Sprintf("%02d", j.t.Minute()))
+		case 'S':
+			res.WriteString(fmt.Sprintf("%02d", j.t.Second()))
+		case '%':
+			res.WriteRune('%')
+		default:
+			res.WriteRune('%')
+			res.WriteRune(runes[i])
+		}
+	}
+	return res.String()
+}
+
+// ParseJalali parse jalali date string with strftime-like layout.
+// Supported verbs are %Y, %m, %d, %B, %b, %H, %M, %S and %%.
+// Persian and arabic-indic digits are accepted.
+//
+// code block:
+//
+//	ParseJalali("%Y/%m/%d", "۱۴۰۳/۰۱/۰۱", time.Local)
+func ParseJalali(layout, value string, loc *time.Location) (Jalali, error) {
+	year, month, day := 0, 1, 1
+	hour, min, sec := 0, 0, 0
+
+	l, v := []rune(layout), []rune(ToEnglishDigits(value))
+	for i := 0; i < len(l); i++ {
+		if l[i] != '%' || i+1 >= len(l) || l[i+1] == '%' {
+			if i+1 < len(l) && l[i] == '%' {
+				i++
+			}
+			if len(v) == 0 || v[0] != l[i] {
+				return Jalali{}, fmt.Errorf("cannot parse %q as %q", value, layout)
+			}
+			v = v[1:]
+			continue
+		}
+
+		i++
+		var err error
+		switch l[i] {
+		case 'Y':
+			year, v, err = parseJalaliNumber(v, 4)
+		case 'm':
+			month, v, err = parseJalaliNumber(v, 2)
+		case 'd':
+			day, v, err = parseJalaliNumber(v, 2)
+		case 'H':
+			hour, v, err = parseJalaliNumber(v, 2)
+		case 'M':
+			min, v, err = parseJalaliNumber(v, 2)
+		case 'S':
+			sec, v, err = parseJalaliNumber(v, 2)
+		case 'B':
+			month, v, err = parseJalaliName(v, JalaliMonths)
+		case 'b':
+			month, v, err = parseJalaliName(v, JalaliMonthsFinglish)
+		default:
+			err = fmt.Errorf("unsupported verb %%%c", l[i])
+		}
+		if err != nil {
+			return Jalali{}, fmt.Errorf("cannot parse %q as %q: %w", value, layout, err)
+		}
+	}
+
+	if len(v) > 0 {
+		return Jalali{}, fmt.Errorf("cannot parse %q as %q: extra text %q", value, layout, string(v))
+	}
+	if hour > 23 || min > 59 || sec > 59 {
+		return Jalali{}, fmt.Errorf("cannot parse %q as %q: invalid time", value, layout)
+	}
+	return JalaliDate(year, month, day, hour, min, sec, 0, loc)
+}
+
+// withDate returns date with clamped day and same clock.
+func (j Jalali) withDate(year, month, day int) Jalali {
+	day = Min(day, JalaliMonthDays(year, month))
+	res, err := JalaliDate(year, month, day, j.t.Hour(), j.t.Minute(), j.t.Second(), j.t.Nanosecond(), j.t.Location())
+	if err != nil {
+		return j
+	}
+	return res
+}
+
+// parseJalaliNumber read up to max digits from value.
+func parseJalaliNumber(v []rune, max int) (int, []rune, error) {
+	n := 0
+	for n < len(v) && n < max && v[n] >= '0' && v[n] <= '9' {
+		n++
+	}
+	if n == 0 {
+		return 0, v, errors.New("expected number")
+	}
+	res, err := strconv.Atoi(string(v[:n]))
+	return res, v[n:], err
+}
+
+// parseJalaliName read longest matching name from value and returns its 1-based index.
+func parseJalaliName(v []rune, names []string) (int, []rune, error) {
+	index, length := 0, 0
+	s := string(v)
+	for i, name := range names {
+		if len(name) > length && strings.HasPrefix(strings.ToLower(s), strings.ToLower(name)) {
+			index, length = i+1, len(name)
+		}
+	}
+	if index == 0 {
+		return 0, v, errors.New("expected month name")
+	}
+	return index, []rune(s[length:]), nil
+}
+
+// jalaliCal returns leap cycle position (0 for leap years),
+// gregorian year of farvardin 1 and its march day.
+func jalaliCal(jy int) (leap, gy, march int) {
+	gy = jy + 621
+	leapJ := -14
+	jp := jalaliBreaks[0]
+
+	jump := 0
+	for i := 1; i < len(jalaliBreaks); i++ {
+		jm := jalaliBreaks[i]
+		jump = jm - jp
+		if jy < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+
+	n := jy - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gy/4 - (gy/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap, gy, march
+}
+
+// jalaliToJDN convert jalali date to julian day number.
+func jalaliToJDN(jy, jm, jd int) int {
+	_, gy, march := jalaliCal(jy)
+	return gregorianToJDN(gy, 3, march) + (jm-1)*31 - jm/7*(jm-7) + jd - 1
+}
+
+// jdnToJalali convert julian day number to jalali date.
+func jdnToJalali(jdn int) (int, int, int) {
+	gy, _, _ := jdnToGregorian(jdn)
+	jy := gy - 621
+	leap, _, march := jalaliCal(jy)
+	k := jdn - gregorianToJDN(gy, 3, march)
+
+	if k >= 0 {
+		if k <= 185 {
+			return jy, 1 + k/31, k%31 + 1
+		}
+		k -= 186
+	} else {
+		jy--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return jy, 7 + k/30, k%30 + 1
+}
+
+// gregorianToJDN convert gregorian date to julian day number.
+func gregorianToJDN(gy, gm, gd int) int {
+	d := (gy+(gm-8)/6+100100)*1461/4 + (153*((gm+9)%12)+2)/5 + gd - 34840408
+	return d - (gy+100100+(gm-8)/6)/100*3/4 + 752
+}
+
+// jdnToGregorian convert julian day number to gregorian date.
+func jdnToGregorian(jdn int) (int, int, int) {
+	j := 4*jdn + 139361631
+	j += (4*jdn+183187720)/146097*3/4*4 - 3908
+	i := j%1461/4*5 + 308
+	gd := i%153/5 + 1
+	gm := i/153%12 + 1
+	gy := j/1461 - 100100 + (8-gm)/6
+	return gy, gm, gd
+}
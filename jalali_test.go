@@ -0,0 +1,158 @@
+package goutils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestNewJalali(t *testing.T) {
+	tests := []struct {
+		gregorian string
+		expected  string
+	}{
+		{"2024-03-20", "1403/01/01"},
+		{"2025-03-20", "1403/12/30"},
+		{"2025-03-21", "1404/01/01"},
+		{"1979-02-11", "1357/11/22"},
+		{"2000-01-01", "1378/10/11"},
+		{"2021-03-20", "1399/12/30"},
+	}
+
+	for _, test := range tests {
+		tm, _ := time.Parse("2006-01-02", test.gregorian)
+		result := goutils.NewJalali(tm)
+		if result.String() != test.expected {
+			t.Errorf("NewJalali(%s) = %s; want %s", test.gregorian, result, test.expected)
+		}
+
+		j, err := goutils.JalaliDate(result.Year(), result.Month(), result.Day(), 0, 0, 0, 0, time.UTC)
+		if err != nil || !j.Time().Equal(tm) {
+			t.Errorf("JalaliDate(%s).Time() = %v, %v; want %s", test.expected, j.Time(), err, test.gregorian)
+		}
+	}
+}
+
+func TestNewJalaliOutOfRange(t *testing.T) {
+	tests := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Time{}, "-061/01/01"},
+		{time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), "3177/12/29"},
+	}
+
+	for _, test := range tests {
+		result := goutils.NewJalali(test.time)
+		if result.String() != test.expected {
+			t.Errorf("NewJalali(%v) = %s; want %s", test.time, result, test.expected)
+		}
+		if back := goutils.NewJalali(result.Time()); back.String() != test.expected {
+			t.Errorf("NewJalali(%v).Time() = %v; want time of %s", test.time, result.Time(), test.expected)
+		}
+	}
+}
+
+func TestJalaliZeroValue(t *testing.T) {
+	var j goutils.Jalali
+	if j.MonthName() != "" || j.MonthNameFinglish() != "" {
+		t.Errorf("Jalali{}.MonthName() = %q, %q; want empty", j.MonthName(), j.MonthNameFinglish())
+	}
+}
+
+func TestJalaliRoundTrip(t *testing.T) {
+	start := time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+	for d := 0; d < 200*366; d += 7 {
+		tm := start.AddDate(0, 0, d)
+		j := goutils.NewJalali(tm)
+		back, err := goutils.JalaliDate(j.Year(), j.Month(), j.Day(), 0, 0, 0, 0, time.UTC)
+		if err != nil || !back.Time().Equal(tm) {
+			t.Fatalf("round trip of %v failed: %s, %v", tm, j, err)
+		}
+	}
+}
+
+func TestIsJalaliLeap(t *testing.T) {
+	tests := map[int]bool{1399: true, 1400: false, 1402: false, 1403: true, 1404: false, 1408: true}
+	for year, expected := range tests {
+		if result := goutils.IsJalaliLeap(year); result != expected {
+			t.Errorf("IsJalaliLeap(%d) = %t; want %t", year, result, expected)
+		}
+	}
+
+	if _, err := goutils.JalaliDate(1404, 12, 30, 0, 0, 0, 0, time.UTC); err == nil {
+		t.Errorf("JalaliDate(1404, 12, 30) should return error")
+	}
+}
+
+func TestJalaliArithmetic(t *testing.T) {
+	j, _ := goutils.JalaliDate(1403, 6, 31, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		result   goutils.Jalali
+		expected string
+	}{
+		{"AddDays", j.AddDays(1), "1403/07/01"},
+		{"AddDays", j.AddDays(-31), "1403/05/31"},
+		{"AddMonths", j.AddMonths(1), "1403/07/30"},
+		{"AddMonths", j.AddMonths(6), "1403/12/30"},
+		{"AddMonths", j.AddMonths(-7), "1402/11/30"},
+		{"AddYears", j.AddMonths(6).AddYears(1), "1404/12/29"},
+	}
+
+	for _, test := range tests {
+		if test.result.String() != test.expected {
+			t.Errorf("%s() = %s; want %s", test.name, test.result, test.expected)
+		}
+		if test.result.Time().Hour() != 10 || test.result.Time().Minute() != 30 {
+			t.Errorf("%s() changed clock to %v", test.name, test.result.Time())
+		}
+	}
+}
+
+func TestJalaliFormat(t *testing.T) {
+	j, _ := goutils.JalaliDate(1403, 1, 1, 8, 5, 9, 0, time.UTC)
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"%Y-%m-%d %H:%M:%S", "1403-01-01 08:05:09"},
+		{"%A %d %B %Y", "چهارشنبه 01 فروردین 1403"},
+		{"%a, %d %b %y", "Chaharshanbe, 01 Farvardin 03"},
+		{"%j 100%%", "001 100%"},
+	}
+
+	for _, test := range tests {
+		if result := j.Format(test.layout); result != test.expected {
+			t.Errorf("Format(%q) = %q; want %q", test.layout, result, test.expected)
+		}
+	}
+}
+
+func TestParseJalali(t *testing.T) {
+	tests := []struct {
+		layout   string
+		value    string
+		expected string
+		valid    bool
+	}{
+		{"%Y/%m/%d", "۱۴۰۳/۰۱/۰۱", "1403/01/01", true},
+		{"%Y/%m/%d %H:%M", "1403/7/5 14:30", "1403/07/05", true},
+		{"%d %B %Y", "12 اسفند 1402", "1402/12/12", true},
+		{"%d %b %Y", "3 ordibehesht 1400", "1400/02/03", true},
+		{"%Y/%m/%d", "1404/12/30", "", false},
+		{"%Y/%m/%d", "1403-01-01", "", false},
+		{"%Y/%m/%d", "1403/01/01 extra", "", false},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.ParseJalali(test.layout, test.value, time.UTC)
+		if test.valid && (err != nil || result.String() != test.expected) {
+			t.Errorf("ParseJalali(%q, %q) = %s, %v; want %s", test.layout, test.value, result, err, test.expected)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("ParseJalali(%q, %q) should return error", test.layout, test.value)
+		}
+	}
+}
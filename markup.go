@@ -0,0 +1,454 @@
+package goutils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// Markdown block and inline patterns.
+var (
+	mdHeadingRx = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdRuleRx    = regexp.MustCompile(`^\s{0,3}((\*\s*){3,}|(-\s*){3,}|(_\s*){3,})$`)
+	mdListRx    = regexp.MustCompile(`^\s{0,3}([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	mdQuoteRx   = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdFenceRx   = regexp.MustCompile("^\\s{0,3}(```+|~~~+)")
+	mdCodeRx    = regexp.MustCompile("`([^`]+)`")
+	mdImageRx   = regexp.MustCompile(`!\[([^\]]*)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	mdLinkRx    = regexp.MustCompile(`\[([^\]]+)\]\(((?:[^()\s]|\([^()\s]*\))+)\)`)
+	mdStrongRx  = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdEmRx      = regexp.MustCompile(`\*([^*\s][^*]*?)\*|\b_([^_\s][^_]*?)_\b`)
+	mdDelRx     = regexp.MustCompile(`~~(.+?)~~`)
+	mdTokenRx   = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// HTMLToText convert html to plain text with structure kept.
+// Block tags and br converted to line breaks, list items prefixed with bullets or numbers,
+// link urls added as footnotes (e.g. "docs [1]" and "[1] https://example.com" at end)
+// and script, style and head contents removed.
+//
+// code block:
+//
+//	HTMLToText(`<p>Read <a href="https://example.com/docs">docs</a></p><ul><li>One</li><li>Two</li></ul>`)
+//
+// output:
+//
+//	Read docs [1]
+//
+//	- One
+//	- Two
+//
+//	[1] https://example.com/docs
+func HTMLToText(data string) PlainText {
+	w := new(textWriter)
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(data))
+	skip := 0
+	pre := 0
+	var lists []htmlList
+	var links []string
+	var linkStack []htmlLink
+	var cells int
+
+	for {
+		tt := tokenizer.Next()
+		if tt == xhtml.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tt {
+		case xhtml.TextToken:
+			if skip > 0 {
+				continue
+			}
+			if pre > 0 {
+				w.writeRaw(token.Data)
+			} else {
+				w.write(token.Data)
+			}
+
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if isSkippedTag(token.Data) {
+				if tt == xhtml.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			switch token.Data {
+			case "br":
+				w.breakLine(1)
+			case "hr":
+				w.breakLine(2)
+				w.writeRaw("---")
+				w.breakLine(2)
+			case "p", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "table", "dl", "figure":
+				w.breakLine(2)
+			case "pre":
+				w.breakLine(2)
+				pre++
+			case "ul", "ol":
+				if len(lists) == 0 {
+					w.breakLine(2)
+				}
+				list := htmlList{ordered: token.Data == "ol", index: 1}
+				if start, err := strconv.Atoi(attrValue(token, "start")); err == nil && list.ordered {
+					list.index = start
+				}
+				lists = append(lists, list)
+			case "li":
+				w.breakLine(1)
+				if len(lists) == 0 {
+					w.writeRaw("- ")
+					continue
+				}
+				list := &lists[len(lists)-1]
+				w.writeRaw(strings.Repeat("  ", len(lists)-1))
+				if list.ordered {
+					w.writeRaw(strconv.Itoa(list.index) + ". ")
+					list.index++
+				} else {
+					w.writeRaw("- ")
+				}
+			case "tr":
+				w.breakLine(1)
+				cells = 0
+			case "td", "th":
+				if cells > 0 {
+					w.writeRaw(" | ")
+				}
+				cells++
+			case "dt", "dd", "div", "section", "article", "header", "footer", "aside", "nav", "main", "address", "caption", "figcaption", "details", "summary":
+				w.breakLine(1)
+			case "img":
+				if alt := strings.TrimSpace(attrValue(token, "alt")); alt != "" {
+					w.write(" " + alt + " ")
+				}
+			case "a":
+				if tt == xhtml.StartTagToken {
+					linkStack = append(linkStack, htmlLink{href: strings.TrimSpace(attrValue(token, "href")), start: w.len()})
+				}
+			}
+
+		case xhtml.EndTagToken:
+			if isSkippedTag(token.Data) {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			switch token.Data {
+			case "p", "h1", "h2", "h3", "h4", "h5", "h6", "blockquote", "table", "dl", "figure":
+				w.breakLine(2)
+			case "pre":
+				if pre > 0 {
+					pre--
+				}
+				w.breakLine(2)
+			case "ul", "ol":
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+				}
+				if len(lists) == 0 {
+					w.breakLine(2)
+				} else {
+					w.breakLine(1)
+				}
+			case "li", "tr", "dt", "dd", "div", "section", "article", "header", "footer", "aside", "nav", "main", "address", "caption", "figcaption", "details", "summary":
+				w.breakLine(1)
+			case "a":
+				if len(linkStack) == 0 {
+					continue
+				}
+				link := linkStack[len(linkStack)-1]
+				linkStack = linkStack[:len(linkStack)-1]
+				text := strings.TrimSpace(w.from(link.start))
+				if isFootnoteURL(link.href) && text != link.href && strings.TrimPrefix(link.href, "mailto:") != text {
+					links = append(links, link.href)
+					w.write(fmt.Sprintf(" [%d]", len(links)))
+				}
+			}
+		}
+	}
+
+	if len(links) > 0 {
+		w.breakLine(2)
+		for i, link := range links {
+			w.writeRaw(fmt.Sprintf("[%d] %s", i+1, link))
+			w.breakLine(1)
+		}
+	}
+	return PlainText(w.String())
+}
+
+// MarkdownToHTML render markdown to html sanitized by CommonSanitizer policy.
+// Supported syntax is headings, paragraphs, line breaks (two trailing spaces), lists, blockquotes,
+// fenced code blocks, horizontal rules, code spans, links, images, bold, italic and strikethrough.
+// Raw html in markdown is escaped.
+//
+// code block:
+//
+//	MarkdownToHTML("# Title\n\nHello **world** [link](javascript:alert(1))")
+//
+// output:
+//
+//	<h1>Title</h1>
+//	<p>Hello <strong>world</strong> link</p>
+func MarkdownToHTML(data string) SafeHTML {
+	data = strings.ReplaceAll(strings.ReplaceAll(data, "\r\n", "\n"), "\r", "\n")
+	return CommonSanitizer.SanitizeHTML(renderMarkdownBlocks(strings.Split(data, "\n")))
+}
+
+// renderMarkdownBlocks render markdown lines to html blocks.
+func renderMarkdownBlocks(lines []string) string {
+	var blocks []string
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, "<p>"+renderMarkdownLines(paragraph)+"</p>")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case mdFenceRx.MatchString(line):
+			flush()
+			fence := mdFenceRx.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, "<pre><code>"+html.EscapeString(strings.Join(code, "\n"))+"</code></pre>")
+
+		case mdHeadingRx.MatchString(line):
+			flush()
+			match := mdHeadingRx.FindStringSubmatch(line)
+			tag := fmt.Sprintf("h%d", len(match[1]))
+			blocks = append(blocks, "<"+tag+">"+renderMarkdownInline(match[2])+"</"+tag+">")
+
+		case mdRuleRx.MatchString(line):
+			flush()
+			blocks = append(blocks, "<hr>")
+
+		case mdQuoteRx.MatchString(line):
+			flush()
+			var quote []string
+			for ; i < len(lines) && mdQuoteRx.MatchString(lines[i]); i++ {
+				quote = append(quote, mdQuoteRx.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			blocks = append(blocks, "<blockquote>"+renderMarkdownBlocks(quote)+"</blockquote>")
+
+		case mdListRx.MatchString(line) && (len(paragraph) == 0 || !isOrderedMarker(line)):
+			flush()
+			ordered := isOrderedMarker(line)
+			var items []string
+			for ; i < len(lines); i++ {
+				if match := mdListRx.FindStringSubmatch(lines[i]); match != nil && isOrderedMarker(lines[i]) == ordered {
+					items = append(items, match[2])
+				} else if strings.TrimSpace(lines[i]) != "" && unicode.IsSpace(rune(lines[i][0])) && !mdListRx.MatchString(lines[i]) {
+					items[len(items)-1] += "\n" + strings.TrimSpace(lines[i])
+				} else {
+					break
+				}
+			}
+			i--
+
+			tag := "ul"
+			if ordered {
+				tag = "ol"
+			}
+			var res strings.Builder
+			res.WriteString("<" + tag + ">")
+			for _, item := range items {
+				res.WriteString("<li>" + renderMarkdownLines(strings.Split(item, "\n")) + "</li>")
+			}
+			res.WriteString("</" + tag + ">")
+			blocks = append(blocks, res.String())
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return strings.Join(blocks, "\n")
+}
+
+// renderMarkdownLines render inline markdown of lines, lines ending with two spaces get line break.
+func renderMarkdownLines(lines []string) string {
+	res := make([]string, len(lines))
+	for i, line := range lines {
+		res[i] = renderMarkdownInline(strings.TrimSpace(line))
+		if i < len(lines)-1 && strings.HasSuffix(line, "  ") {
+			res[i] += "<br>"
+		}
+	}
+	return strings.Join(res, "\n")
+}
+
+// renderMarkdownInline render code spans, images, links and emphasis of escaped text.
+func renderMarkdownInline(text string) string {
+	var tokens []string
+	store := func(s string) string {
+		tokens = append(tokens, s)
+		return fmt.Sprintf("\x00%d\x00", len(tokens)-1)
+	}
+
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = mdCodeRx.ReplaceAllStringFunc(text, func(s string) string {
+		return store("<code>" + html.EscapeString(mdCodeRx.FindStringSubmatch(s)[1]) + "</code>")
+	})
+	text = html.EscapeString(text)
+	text = mdImageRx.ReplaceAllStringFunc(text, func(s string) string {
+		match := mdImageRx.FindStringSubmatch(s)
+		return store(`<img src="` + match[2] + `" alt="` + match[1] + `">`)
+	})
+	text = mdLinkRx.ReplaceAllStringFunc(text, func(s string) string {
+		match := mdLinkRx.FindStringSubmatch(s)
+		return store(`<a href="` + match[2] + `">` + renderMarkdownEmphasis(match[1]) + `</a>`)
+	})
+	text = renderMarkdownEmphasis(text)
+
+	// Restore tokens, links may contain nested tokens (e.g. code or image)
+	for mdTokenRx.MatchString(text) {
+		text = mdTokenRx.ReplaceAllStringFunc(text, func(s string) string {
+			idx, _ := strconv.Atoi(mdTokenRx.FindStringSubmatch(s)[1])
+			return tokens[idx]
+		})
+	}
+	return text
+}
+
+// renderMarkdownEmphasis render bold, italic and strikethrough.
+func renderMarkdownEmphasis(text string) string {
+	text = mdStrongRx.ReplaceAllString(text, "<strong>$1$2</strong>")
+	text = mdEmRx.ReplaceAllString(text, "<em>$1$2</em>")
+	return mdDelRx.ReplaceAllString(text, "<del>$1</del>")
+}
+
+// isOrderedMarker check if line is ordered list item.
+func isOrderedMarker(line string) bool {
+	match := mdListRx.FindStringSubmatch(line)
+	return match != nil && isDigit(rune(match[1][0]))
+}
+
+// htmlList represents list state in HTMLToText.
+type htmlList struct {
+	ordered bool
+	index   int
+}
+
+// htmlLink represents open link in HTMLToText.
+type htmlLink struct {
+	href  string
+	start int
+}
+
+// textWriter write text with collapsed whitespaces and controlled line breaks.
+type textWriter struct {
+	buf      strings.Builder
+	newlines int
+	space    bool
+}
+
+// write append text with whitespaces collapsed.
+func (w *textWriter) write(s string) {
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			w.space = w.buf.Len() > 0 && w.newlines == 0
+			continue
+		}
+		if w.space {
+			w.buf.WriteByte(' ')
+			w.space = false
+		}
+		w.buf.WriteRune(r)
+		w.newlines = 0
+	}
+}
+
+// writeRaw append text as is.
+func (w *textWriter) writeRaw(s string) {
+	if s == "" {
+		return
+	}
+	if w.space {
+		w.buf.WriteByte(' ')
+		w.space = false
+	}
+	w.buf.WriteString(s)
+	w.newlines = len(s) - len(strings.TrimRight(s, "\n"))
+}
+
+// breakLine ensure text ends with at least n line breaks.
+func (w *textWriter) breakLine(n int) {
+	w.space = false
+	if w.buf.Len() == 0 {
+		return
+	}
+	for ; w.newlines < n; w.newlines++ {
+		w.buf.WriteByte('\n')
+	}
+}
+
+// len returns written text length.
+func (w *textWriter) len() int {
+	return w.buf.Len()
+}
+
+// from returns text written after offset.
+func (w *textWriter) from(offset int) string {
+	return w.buf.String()[offset:]
+}
+
+// String returns text with trailing spaces of lines and text removed.
+func (w *textWriter) String() string {
+	lines := strings.Split(w.buf.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// attrValue returns attribute value of html token.
+func attrValue(token xhtml.Token, name string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// isSkippedTag check if tag content must be removed in text.
+func isSkippedTag(tag string) bool {
+	switch tag {
+	case "script", "style", "head", "title", "noscript", "template", "iframe", "object", "svg", "math":
+		return true
+	}
+	return false
+}
+
+// isFootnoteURL check if link url should be added to footnotes.
+func isFootnoteURL(href string) bool {
+	lower := strings.ToLower(href)
+	return href != "" && !strings.HasPrefix(href, "#") &&
+		!strings.HasPrefix(lower, "javascript:") && !strings.HasPrefix(lower, "data:")
+}
@@ -0,0 +1,76 @@
+package goutils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<p>Hello   <b>World</b></p>`, "Hello World"},
+		{`<p>One</p><p>Two<br>Three</p>`, "One\n\nTwo\nThree"},
+		{`<ul><li>One</li><li>Two<ul><li>Nested</li></ul></li></ul>`, "- One\n- Two\n  - Nested"},
+		{`<ol start="3"><li>Three</li><li>Four</li></ol>`, "3. Three\n4. Four"},
+		{
+			`<p>Read <a href="https://example.com/docs">docs</a> and <a href="https://a.com">https://a.com</a></p><p><a href="https://b.com">b</a></p>`,
+			"Read docs [1] and https://a.com\n\nb [2]\n\n[1] https://example.com/docs\n[2] https://b.com",
+		},
+		{`<a href="#top">top</a> <a href="javascript:alert(1)">x</a> <a href="mailto:a@b.com">a@b.com</a>`, "top x a@b.com"},
+		{`<head><title>T</title><style>p{color:red}</style></head><script>alert(1)</script>text`, "text"},
+		{`<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>`, "A | B\n1 | 2"},
+		{"<pre>  a\n  b</pre><p>c</p>", "a\n  b\n\nc"},
+		{`<p>Tom &amp; Jerry &lt;3</p><hr><div>see <img src="x.png" alt="photo"></div>`, "Tom & Jerry <3\n\n---\n\nsee photo"},
+		{`<p>سلام <b>دنیا</b></p>`, "سلام دنیا"},
+	}
+
+	for _, test := range tests {
+		if result := goutils.HTMLToText(test.input); string(result) != test.expected {
+			t.Errorf("HTMLToText(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"# Title #\n## C#", "<h1>Title</h1>\n<h2>C#</h2>"},
+		{"Hello **bold** *em* _em_ ~~del~~", "<p>Hello <strong>bold</strong> <em>em</em> <em>em</em> <del>del</del></p>"},
+		{"line one  \nline two\n\nnext", "<p>line one<br>\nline two</p>\n<p>next</p>"},
+		{"- a\n- b\n  continued\n\n1. one\n2. two", "<ul><li>a</li><li>b\ncontinued</li></ul>\n<ol><li>one</li><li>two</li></ol>"},
+		{"> quote **b**\n> more", "<blockquote><p>quote <strong>b</strong>\nmore</p></blockquote>"},
+		{"```go\nfmt.Println(\"<x>\")\n```", "<pre><code>fmt.Println(&#34;&lt;x&gt;&#34;)</code></pre>"},
+		{"a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>"},
+		{"`a <b> **c**`", "<p><code>a &lt;b&gt; **c**</code></p>"},
+		{"[docs](https://example.com/a_b_c)", `<p><a href="https://example.com/a_b_c" rel="nofollow">docs</a></p>`},
+		{"[wiki](https://en.wikipedia.org/wiki/Go_(language))", `<p><a href="https://en.wikipedia.org/wiki/Go_(language)" rel="nofollow">wiki</a></p>`},
+		{"![logo](https://example.com/logo.png)", `<p><img src="https://example.com/logo.png" alt="logo"></p>`},
+		{"[x](javascript:alert(1))", "<p>x</p>"},
+		{"![x](javascript:alert(1))", `<p><img alt="x"></p>`},
+		{`[x](https://a.com" onclick="alert(1))`, `<p>[x](https://a.com&#34; onclick=&#34;alert(1))</p>`},
+		{"<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"<img src=x onerror=alert(1)>", "<p>&lt;img src=x onerror=alert(1)&gt;</p>"},
+	}
+
+	for _, test := range tests {
+		if result := goutils.MarkdownToHTML(test.input); string(result) != test.expected {
+			t.Errorf("MarkdownToHTML(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestMarkdownToHTMLXSSCorpus(t *testing.T) {
+	for _, input := range xssCorpus {
+		result := goutils.MarkdownToHTML(input).String()
+		assertSafeHTML(t, input, result)
+		if strings.Contains(strings.ToLower(result), "<script") {
+			t.Errorf("MarkdownToHTML(%q) = %q; contains script", input, result)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package goutils
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrMaskMismatch returned when value digits does not fit mask pattern.
+var ErrMaskMismatch = errors.New("value does not match mask")
+
+// Mask represents digit input mask (e.g. "#### #### #### ####" or "09##-###-####").
+// # is digit placeholder and other characters are literal.
+// Literal digits in pattern are consumed from value when present in place,
+// leading literal digits may be omitted (e.g. "09121234567", "9121234567"
+// and "121234567" for "09##-###-####").
+// Persian and arabic-indic digits accepted as input.
+type Mask struct {
+	pattern  []rune
+	slots    int
+	literals int
+}
+
+// NewMask create mask from pattern.
+func NewMask(pattern string) *Mask {
+	m := &Mask{
+		pattern: []rune(pattern),
+		slots:   strings.Count(pattern, "#"),
+	}
+	for _, p := range m.pattern {
+		if isDigit(p) {
+			m.literals++
+		}
+	}
+	return m
+}
+
+// Pattern returns mask pattern.
+func (m *Mask) Pattern() string {
+	return string(m.pattern)
+}
+
+// Slots returns number of digit placeholders.
+func (m *Mask) Slots() int {
+	return m.slots
+}
+
+// Format fill mask pattern with value digits.
+//
+// code block:
+//
+//	NewMask("(####) ###-####").Format("09121234567")
+//
+// output:
+//
+//	(0912) 123-4567
+func (m *Mask) Format(value string) (string, error) {
+	res, _, err := m.fill(value)
+	return res, err
+}
+
+// Unmask strip mask literals from formatted value and returns digits.
+//
+// code block:
+//
+//	NewMask("(####) ###-####").Unmask("(۰۹۱۲) ۱۲۳-۴۵۶۷")
+//
+// output:
+//
+//	09121234567
+func (m *Mask) Unmask(value string) (string, error) {
+	res, _, err := m.fill(value)
+	if err != nil {
+		return "", err
+	}
+	return ExtractNumbers(res), nil
+}
+
+// Redact format value and replace all placeholder digits except last visible digits with *.
+//
+// code block:
+//
+//	NewMask("#### #### #### ####").Redact("6037991123451234", 4)
+//
+// output:
+//
+//	**** **** **** 1234
+func (m *Mask) Redact(value string, visible int) (string, error) {
+	res, slots, err := m.fill(value)
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(res)
+	for i := 0; i < len(slots)-Max(visible, 0); i++ {
+		runes[slots[i]] = '*'
+	}
+	return string(runes), nil
+}
+
+// fill returns formatted value and rune index of filled placeholders.
+func (m *Mask) fill(value string) (string, []int, error) {
+	digits := []rune(ExtractLocalizedNumbers(value))
+	skip := m.omittedLiterals(digits)
+	if skip < 0 {
+		return "", nil, ErrMaskMismatch
+	}
+
+	literal := 0
+	res := make([]rune, 0, len(m.pattern))
+	slots := make([]int, 0, m.slots)
+	for _, p := range m.pattern {
+		switch {
+		case p == '#':
+			slots = append(slots, len(res))
+			res = append(res, digits[0])
+			digits = digits[1:]
+		case isDigit(p):
+			if literal >= skip {
+				digits = digits[1:]
+			}
+			literal++
+			res = append(res, p)
+		default:
+			res = append(res, p)
+		}
+	}
+	return string(res), slots, nil
+}
+
+// omittedLiterals returns number of leading pattern literal digits omitted from digits
+// or -1 if digits does not fit pattern.
+func (m *Mask) omittedLiterals(digits []rune) int {
+	skip := m.slots + m.literals - len(digits)
+	if skip < 0 || skip > m.literals {
+		return -1
+	}
+
+	i, literal := 0, 0
+	for _, p := range m.pattern {
+		switch {
+		case p == '#':
+			i++
+		case isDigit(p):
+			if literal >= skip {
+				if digits[i] != p {
+					return -1
+				}
+				i++
+			}
+			literal++
+		}
+	}
+	return skip
+}
@@ -0,0 +1,86 @@
+package goutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestMaskFormat(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		value    string
+		expected string
+	}{
+		{"#### #### #### ####", "6037991123451234", "6037 9911 2345 1234"},
+		{"(####) ###-####", "09121234567", "(0912) 123-4567"},
+		{"09##-###-####", "09121234567", "0912-123-4567"},
+		{"09##-###-####", "9121234567", "0912-123-4567"},
+		{"09##-###-####", "121234567", "0912-123-4567"},
+		{"###-######-#", "۰۰۱۲۳۴۵۶۷۸", "001-234567-8"},
+		{"##/##", "٠١/٢٣", "01/23"},
+		{"+98 ### ### ####", "9121234567", "+98 912 123 4567"},
+		{"+98 ### ### ####", "989121234567", "+98 912 123 4567"},
+		{"0### ### ####", "0012345678", "0001 234 5678"},
+		{"0### ### ####", "00012345678", "0001 234 5678"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.NewMask(test.pattern).Format(test.value)
+		if err != nil || result != test.expected {
+			t.Errorf("Mask(%q).Format(%q) = %q, %v; want %q", test.pattern, test.value, result, err, test.expected)
+		}
+	}
+
+	if _, err := goutils.NewMask("09##-###-####").Format("19121234567"); !errors.Is(err, goutils.ErrMaskMismatch) {
+		t.Errorf("Format with mismatched literal digits error = %v; want ErrMaskMismatch", err)
+	}
+
+	for _, invalid := range []string{"123", "12345678901234567"} {
+		if _, err := goutils.NewMask("#### #### #### ####").Format(invalid); !errors.Is(err, goutils.ErrMaskMismatch) {
+			t.Errorf("Format(%q) error = %v; want ErrMaskMismatch", invalid, err)
+		}
+	}
+}
+
+func TestMaskUnmask(t *testing.T) {
+	mask := goutils.NewMask("(####) ###-####")
+	tests := map[string]string{
+		"(0912) 123-4567": "09121234567",
+		"(۰۹۱۲) ۱۲۳-۴۵۶۷": "09121234567",
+		"0912 123 4567":   "09121234567",
+	}
+
+	for value, expected := range tests {
+		result, err := mask.Unmask(value)
+		if err != nil || result != expected {
+			t.Errorf("Unmask(%q) = %q, %v; want %q", value, result, err, expected)
+		}
+	}
+
+	if _, err := mask.Unmask("(0912) 123"); err == nil {
+		t.Errorf("Unmask with missing digits should return error")
+	}
+}
+
+func TestMaskRedact(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		value    string
+		visible  int
+		expected string
+	}{
+		{"#### #### #### ####", "6037991123451234", 4, "**** **** **** 1234"},
+		{"09##-###-####", "09121234567", 4, "09**-***-4567"},
+		{"####", "1234", 10, "1234"},
+		{"####", "1234", 0, "****"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.NewMask(test.pattern).Redact(test.value, test.visible)
+		if err != nil || result != test.expected {
+			t.Errorf("Mask(%q).Redact(%q, %d) = %q, %v; want %q", test.pattern, test.value, test.visible, result, err, test.expected)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package goutils
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"golang.org/x/exp/constraints"
 )
@@ -61,3 +66,115 @@ func Max[T constraints.Integer | constraints.Float](numbers ...T) T {
 	}
 	return res
 }
+
+// ErrInvalidNumber returned when localized number string is malformed.
+var ErrInvalidNumber = errors.New("invalid number")
+
+// ParseLocalizedInt parse integer string with persian, arabic-indic or ascii
+// digits and optional thousands separators (e.g. "۱۲٬۳۴۵" or "12,345").
+func ParseLocalizedInt(s string) (int64, error) {
+	normalized, err := normalizeLocalizedNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	if strings.Contains(normalized, ".") {
+		return 0, fmt.Errorf("%w: %q has fraction part", ErrInvalidNumber, s)
+	}
+	n, err := strconv.ParseInt(normalized, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %v", ErrInvalidNumber, s, err)
+	}
+	return n, nil
+}
+
+// ParseLocalizedFloat parse decimal string with persian, arabic-indic or ascii
+// digits, optional thousands separators and decimal separator (e.g. "۱۲٬۳۴۵٫۶۷" or "1,234.5").
+func ParseLocalizedFloat(s string) (float64, error) {
+	normalized, err := normalizeLocalizedNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q: %v", ErrInvalidNumber, s, err)
+	}
+	return n, nil
+}
+
+// ParseLocalizedRat parse localized decimal string to exact rational number.
+func ParseLocalizedRat(s string) (*big.Rat, error) {
+	normalized, err := normalizeLocalizedNumber(s)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(normalized)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	}
+	return r, nil
+}
+
+// normalizeLocalizedNumber validate localized number and returns
+// ascii form with optional sign and "." decimal separator.
+func normalizeLocalizedNumber(s string) (string, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidNumber, s)
+	runes := []rune(strings.TrimSpace(s))
+
+	var res strings.Builder
+	if len(runes) > 0 {
+		switch runes[0] {
+		case '-', '−':
+			res.WriteByte('-')
+			runes = runes[1:]
+		case '+':
+			runes = runes[1:]
+		}
+	}
+
+	// groups contains length of integer part digit groups
+	groups := []int{0}
+	fraction, fractionDigits := false, 0
+	for _, r := range ToEnglishDigits(string(runes)) {
+		switch {
+		case r >= '0' && r <= '9':
+			res.WriteRune(r)
+			if fraction {
+				fractionDigits++
+			} else {
+				groups[len(groups)-1]++
+			}
+		case r == ',' || r == '٬' || r == '،':
+			if fraction {
+				return "", invalid
+			}
+			groups = append(groups, 0)
+		case r == '.' || r == '٫':
+			if fraction {
+				return "", invalid
+			}
+			fraction = true
+			res.WriteByte('.')
+		default:
+			return "", invalid
+		}
+	}
+
+	// Validate digit groups
+	if fraction && fractionDigits == 0 {
+		return "", invalid
+	}
+	if groups[0] == 0 && (len(groups) > 1 || !fraction) {
+		return "", invalid
+	}
+	if len(groups) > 1 {
+		if groups[0] > 3 {
+			return "", invalid
+		}
+		for _, g := range groups[1:] {
+			if g != 3 {
+				return "", invalid
+			}
+		}
+	}
+	return res.String(), nil
+}
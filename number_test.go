@@ -1,6 +1,9 @@
 package goutils_test
 
 import (
+	"errors"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/mekramy/goutils"
@@ -130,3 +133,70 @@ func TestMax(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLocalizedInt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		valid    bool
+	}{
+		{input: "۱۲٬۳۴۵", expected: 12345, valid: true},
+		{input: "1,234,567", expected: 1234567, valid: true},
+		{input: " -٤٢ ", expected: -42, valid: true},
+		{input: "+100", expected: 100, valid: true},
+		{input: "12,34", valid: false},
+		{input: "1234,567", valid: false},
+		{input: "12.5", valid: false},
+		{input: "12a", valid: false},
+		{input: "", valid: false},
+		{input: "-", valid: false},
+		{input: "9,223,372,036,854,775,808", valid: false},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.ParseLocalizedInt(test.input)
+		if test.valid && (err != nil || result != test.expected) {
+			t.Errorf("ParseLocalizedInt(%q) = %d, %v; want %d", test.input, result, err, test.expected)
+		}
+		if !test.valid && !errors.Is(err, goutils.ErrInvalidNumber) {
+			t.Errorf("ParseLocalizedInt(%q) error = %v; want ErrInvalidNumber", test.input, err)
+		}
+	}
+}
+
+func TestParseLocalizedFloat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		valid    bool
+	}{
+		{input: "۱۲٬۳۴۵٫۶۷", expected: 12345.67, valid: true},
+		{input: "1,234.5", expected: 1234.5, valid: true},
+		{input: ".5", expected: 0.5, valid: true},
+		{input: "−۳٫۵", expected: -3.5, valid: true},
+		{input: "1.2.3", valid: false},
+		{input: "1.", valid: false},
+		{input: "1.234,5", valid: false},
+		{input: ",123", valid: false},
+		{input: "1" + strings.Repeat("0", 400), valid: false},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.ParseLocalizedFloat(test.input)
+		if test.valid && (err != nil || result != test.expected) {
+			t.Errorf("ParseLocalizedFloat(%q) = %v, %v; want %v", test.input, result, err, test.expected)
+		}
+		if !test.valid && !errors.Is(err, goutils.ErrInvalidNumber) {
+			t.Errorf("ParseLocalizedFloat(%q) error = %v; want ErrInvalidNumber", test.input, err)
+		}
+	}
+}
+
+func TestParseLocalizedRat(t *testing.T) {
+	input := "۱٬۰۰۰٫۱"
+	expected := big.NewRat(10001, 10)
+	result, err := goutils.ParseLocalizedRat(input)
+	if err != nil || result.Cmp(expected) != 0 {
+		t.Errorf("ParseLocalizedRat(%q) = %v, %v; want %v", input, result, err, expected)
+	}
+}
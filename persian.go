@@ -0,0 +1,145 @@
+package goutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const zwnj = '\u200c'
+
+// PersianOption represents persian text normalization steps.
+// Options can be combined with | operator.
+type PersianOption uint
+
+const (
+	// PersianUnifyChars replace arabic characters (ي ى ك ة ٱ ۀ) with persian equivalents.
+	PersianUnifyChars PersianOption = 1 << iota
+	// PersianDigitsToASCII convert persian and arabic-indic digits to ascii digits.
+	PersianDigitsToASCII
+	// PersianDigitsFromASCII convert ascii and arabic-indic digits to persian digits.
+	PersianDigitsFromASCII
+	// PersianZWNJ remove repeated and misplaced zero width non-joiners.
+	PersianZWNJ
+	// PersianDiacritics remove diacritics (harakat) and tatweel.
+	// Hamza above heh (ezafe, e.g. خانهٔ) is kept.
+	PersianDiacritics
+	// PersianSpaces collapse whitespaces and trim string.
+	PersianSpaces
+
+	// PersianAll apply all normalizations and convert digits to ascii.
+	PersianAll = PersianUnifyChars | PersianDigitsToASCII | PersianZWNJ | PersianDiacritics | PersianSpaces
+)
+
+var persianUnifyReplacer = strings.NewReplacer(
+	"ي", "ی",
+	"ى", "ی",
+	"ك", "ک",
+	"ة", "ه",
+	"ٱ", "ا",
+	"ۀ", "هٔ",
+)
+
+// NormalizePersian normalize persian text with options.
+// Steps are applied in order: unify, digits, diacritics, zwnj, spaces.
+//
+// code block:
+//
+//	NormalizePersian("كتاب  هاي ۱۲", PersianAll)
+//
+// output:
+//
+//	کتاب های 12
+func NormalizePersian(s string, options PersianOption) string {
+	if options&PersianUnifyChars != 0 {
+		s = persianUnifyReplacer.Replace(s)
+	}
+	if options&PersianDigitsToASCII != 0 {
+		s = ToEnglishDigits(s)
+	}
+	if options&PersianDigitsFromASCII != 0 {
+		s = ToPersianDigits(s)
+	}
+	if options&PersianDiacritics != 0 {
+		s = removePersianDiacritics(s)
+	}
+	if options&PersianZWNJ != 0 {
+		s = normalizeZWNJ(s)
+	}
+	if options&PersianSpaces != 0 {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
+
+// ToEnglishDigits convert persian (۰-۹) and arabic-indic (٠-٩) digits to ascii digits.
+func ToEnglishDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '۰' && r <= '۹':
+			return r - '۰' + '0'
+		case r >= '٠' && r <= '٩':
+			return r - '٠' + '0'
+		}
+		return r
+	}, s)
+}
+
+// ToPersianDigits convert ascii and arabic-indic (٠-٩) digits to persian digits (۰-۹).
+func ToPersianDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r - '0' + '۰'
+		case r >= '٠' && r <= '٩':
+			return r - '٠' + '۰'
+		}
+		return r
+	}, s)
+}
+
+// isPersianDiacritic check if rune is arabic diacritic or tatweel.
+func isPersianDiacritic(r rune) bool {
+	return (r >= '\u064B' && r <= '\u065F') || r == '\u0670' || r == '\u0640'
+}
+
+// removePersianDiacritics remove diacritics and tatweel except hamza above heh.
+func removePersianDiacritics(s string) string {
+	var res strings.Builder
+	prev := rune(0)
+	for _, r := range s {
+		if isPersianDiacritic(r) && (r != '\u0654' || prev != 'ه') {
+			continue
+		}
+		res.WriteRune(r)
+		prev = r
+	}
+	return res.String()
+}
+
+// isPersianNonJoining check if letter never join to next letter.
+func isPersianNonJoining(r rune) bool {
+	return strings.ContainsRune("اآأإدذرزژو", r)
+}
+
+// normalizeZWNJ keep zero width non-joiners only between two letters
+// where previous letter can join to next letter.
+func normalizeZWNJ(s string) string {
+	runes := []rune(s)
+	res := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if r != zwnj {
+			res = append(res, r)
+			continue
+		}
+
+		if len(res) == 0 || i+1 >= len(runes) {
+			continue
+		}
+		prev, next := res[len(res)-1], runes[i+1]
+		if !unicode.IsLetter(prev) || !unicode.IsLetter(next) || isPersianNonJoining(prev) {
+			continue
+		}
+		res = append(res, r)
+	}
+	return string(res)
+}
@@ -0,0 +1,53 @@
+package goutils_test
+
+import (
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestNormalizePersian(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  goutils.PersianOption
+		expected string
+	}{
+		{"كتاب هاي علمي", goutils.PersianUnifyChars, "کتاب های علمی"},
+		{"تلفن: ۰۹۱۲-٣٤٥", goutils.PersianDigitsToASCII, "تلفن: 0912-345"},
+		{"شماره 12 و ٣٤", goutils.PersianDigitsFromASCII, "شماره ۱۲ و ۳۴"},
+		{"مـــدرسَه", goutils.PersianDiacritics, "مدرسه"},
+		{"می‌‌خواهم", goutils.PersianZWNJ, "می‌خواهم"},
+		{"‌کتاب‌ ها‌", goutils.PersianZWNJ, "کتاب ها"},
+		{"خانه‌ها و در‌ها", goutils.PersianZWNJ, "خانه‌ها و درها"},
+		{"  سلام \n  دنیا  ", goutils.PersianSpaces, "سلام دنیا"},
+		{"  كتاب‌‌هاي   ۱۲ جلدي  ", goutils.PersianAll, "کتاب‌های 12 جلدی"},
+		{"خانهٔ ۀ", goutils.PersianAll, "خانهٔ هٔ"},
+		{"خانۀ مَن", goutils.PersianUnifyChars | goutils.PersianDiacritics, "خانهٔ من"},
+		{"سٔلام", goutils.PersianDiacritics, "سلام"},
+	}
+
+	for _, test := range tests {
+		result := goutils.NormalizePersian(test.input, test.options)
+		if result != test.expected {
+			t.Errorf("NormalizePersian(%q, %d) = %q; want %q", test.input, test.options, result, test.expected)
+		}
+	}
+}
+
+func TestToEnglishDigits(t *testing.T) {
+	input := "۰۱۲۳۴۵۶۷۸۹ ٠١٢٣٤٥٦٧٨٩"
+	expected := "0123456789 0123456789"
+	result := goutils.ToEnglishDigits(input)
+	if result != expected {
+		t.Errorf("ToEnglishDigits(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+func TestToPersianDigits(t *testing.T) {
+	input := "0123456789 ٠١٢٣٤٥٦٧٨٩"
+	expected := "۰۱۲۳۴۵۶۷۸۹ ۰۱۲۳۴۵۶۷۸۹"
+	result := goutils.ToPersianDigits(input)
+	if result != expected {
+		t.Errorf("ToPersianDigits(%q) = %q; want %q", input, result, expected)
+	}
+}
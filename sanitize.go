@@ -0,0 +1,462 @@
+package goutils
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// Iframe hosts of popular video platforms.
+var (
+	YouTubeHosts = []string{"www.youtube.com", "youtube.com", "www.youtube-nocookie.com"}
+	AparatHosts  = []string{"www.aparat.com", "aparat.com"}
+)
+
+// Preset sanitizer policies.
+var (
+	// RawSanitizer strip all html tags.
+	RawSanitizer = NewSanitizerPolicy()
+
+	// CommonSanitizer allow user generated content formatting, links, lists, tables and images.
+	// Links get rel="nofollow".
+	CommonSanitizer = NewSanitizerPolicy().
+			rule(allowCommonElements).
+			AllowImages().
+			NoFollow(true)
+)
+
+// sanitizeRules contains available struct field sanitize rules.
+var sanitizeRules = map[string]func(string) string{
+	"raw":     func(s string) string { return SanitizeRaw(s, false) },
+	"escape":  RawSanitizer.Sanitize,
+	"trim":    strings.TrimSpace,
+	"html":    func(s string) string { return string(SanitizeHTML(s)) },
+	"slug":    func(s string) string { return Slugify(s) },
+	"persian": func(s string) string { return NormalizePersian(s, PersianAll&^PersianDigitsToASCII) },
+	"digits":  ToEnglishDigits,
+}
+
+// SafeHTML is sanitized html safe to embed in html context.
+type SafeHTML string
+
+// String returns html string.
+func (h SafeHTML) String() string {
+	return string(h)
+}
+
+// Template returns html for html/template without escaping.
+func (h SafeHTML) Template() template.HTML {
+	return template.HTML(h)
+}
+
+// PlainText is unescaped text without html tags for non html contexts
+// (e.g. plain text emails, sms and json). PlainText must be escaped before embedding in html.
+type PlainText string
+
+// String returns text string.
+func (t PlainText) String() string {
+	return string(t)
+}
+
+// HTML returns escaped text safe to embed in html.
+func (t PlainText) HTML() SafeHTML {
+	return SafeHTML(html.EscapeString(string(t)))
+}
+
+// SanitizeHTML sanitize input to html with CommonSanitizer policy.
+func SanitizeHTML(data string) SafeHTML {
+	return CommonSanitizer.SanitizeHTML(data)
+}
+
+// SanitizeText strip all html tags and unescape entities.
+//
+// code block:
+//
+//	SanitizeText("<b>Tom &amp; Jerry</b> &lt;3")
+//
+// output:
+//
+//	Tom & Jerry <3
+func SanitizeText(data string) PlainText {
+	return PlainText(html.UnescapeString(RawSanitizer.Sanitize(data)))
+}
+
+// SanitizerPolicy build html sanitization policy.
+// SanitizerPolicy is immutable, each method returns new policy.
+// Policy compiled once on first Sanitize call and safe for concurrent use.
+type SanitizerPolicy struct {
+	rules       []func(*bluemonday.Policy)
+	schemes     []string
+	noFollow    bool
+	targetBlank bool
+	images      bool
+	imageHosts  []string
+	iframeHosts []string
+	compiled    *compiledSanitizer
+}
+
+type compiledSanitizer struct {
+	once   sync.Once
+	policy *bluemonday.Policy
+}
+
+// NewSanitizerPolicy create policy that strip all html tags.
+// Use builder methods to allow tags and attributes.
+//
+// code block:
+//
+//	policy := CommonSanitizer.AllowYouTube().TargetBlank(true)
+//	policy.Sanitize(`<a href="https://example.com" onclick="x()">link</a>`)
+//
+// output:
+//
+//	<a href="https://example.com" rel="nofollow noopener" target="_blank">link</a>
+func NewSanitizerPolicy() *SanitizerPolicy {
+	return &SanitizerPolicy{compiled: new(compiledSanitizer)}
+}
+
+// AllowElements allow html tags without attributes.
+func (p *SanitizerPolicy) AllowElements(tags ...string) *SanitizerPolicy {
+	return p.rule(func(policy *bluemonday.Policy) {
+		policy.AllowElements(tags...)
+	})
+}
+
+// AllowAttrs allow attribute on tags, attribute allowed globally if no tag passed.
+// Allowing attribute on tag allows tag too.
+func (p *SanitizerPolicy) AllowAttrs(attr string, tags ...string) *SanitizerPolicy {
+	return p.AllowAttrsMatching(attr, nil, tags...)
+}
+
+// AllowAttrsMatching allow attribute with value matching regex on tags.
+func (p *SanitizerPolicy) AllowAttrsMatching(attr string, rx *regexp.Regexp, tags ...string) *SanitizerPolicy {
+	return p.rule(func(policy *bluemonday.Policy) {
+		builder := policy.AllowAttrs(attr)
+		if rx != nil {
+			builder.Matching(rx)
+		}
+		if len(tags) == 0 {
+			builder.Globally()
+		} else {
+			builder.OnElements(tags...)
+		}
+	})
+}
+
+// AllowURLSchemes replace allowed url schemes of links and sources.
+// Default schemes are http, https and mailto. Relative urls always allowed.
+func (p *SanitizerPolicy) AllowURLSchemes(schemes ...string) *SanitizerPolicy {
+	res := p.clone()
+	res.schemes = append([]string(nil), schemes...)
+	return res
+}
+
+// NoFollow add rel="nofollow" to links.
+func (p *SanitizerPolicy) NoFollow(enable bool) *SanitizerPolicy {
+	res := p.clone()
+	res.noFollow = enable
+	return res
+}
+
+// TargetBlank add target="_blank" and rel="noopener" to fully qualified links.
+func (p *SanitizerPolicy) TargetBlank(enable bool) *SanitizerPolicy {
+	res := p.clone()
+	res.targetBlank = enable
+	return res
+}
+
+// AllowImages allow img tags with src on hosts, replaces previously allowed image hosts.
+// Images from any host allowed if no host passed. Relative sources always allowed.
+func (p *SanitizerPolicy) AllowImages(hosts ...string) *SanitizerPolicy {
+	res := p.clone()
+	res.images = true
+	res.imageHosts = append([]string(nil), hosts...)
+	return res
+}
+
+// AllowIframes allow https iframes embedded from hosts.
+func (p *SanitizerPolicy) AllowIframes(hosts ...string) *SanitizerPolicy {
+	res := p.clone()
+	res.iframeHosts = append(res.iframeHosts, hosts...)
+	return res
+}
+
+// AllowYouTube allow youtube embedded videos.
+func (p *SanitizerPolicy) AllowYouTube() *SanitizerPolicy {
+	return p.AllowIframes(YouTubeHosts...)
+}
+
+// AllowAparat allow aparat embedded videos.
+func (p *SanitizerPolicy) AllowAparat() *SanitizerPolicy {
+	return p.AllowIframes(AparatHosts...)
+}
+
+// Sanitize returns html with disallowed tags and attributes removed.
+func (p *SanitizerPolicy) Sanitize(data string) string {
+	return p.policy().Sanitize(data)
+}
+
+// SanitizeHTML sanitize input and returns typed safe html.
+func (p *SanitizerPolicy) SanitizeHTML(data string) SafeHTML {
+	return SafeHTML(p.Sanitize(data))
+}
+
+// policy returns compiled bluemonday policy.
+func (p *SanitizerPolicy) policy() *bluemonday.Policy {
+	p.compiled.once.Do(func() {
+		policy := bluemonday.NewPolicy()
+		for _, rule := range p.rules {
+			rule(policy)
+		}
+
+		schemes := p.schemes
+		if len(schemes) == 0 {
+			schemes = []string{"http", "https", "mailto"}
+		}
+		policy.RequireParseableURLs(true)
+		policy.AllowRelativeURLs(true)
+		policy.AllowURLSchemes(schemes...)
+		policy.RequireNoFollowOnLinks(p.noFollow)
+		policy.AddTargetBlankToFullyQualifiedLinks(p.targetBlank)
+
+		if p.images {
+			policy.AllowAttrs("align").Matching(bluemonday.ImageAlign).OnElements("img")
+			policy.AllowAttrs("alt").Matching(bluemonday.Paragraph).OnElements("img")
+			policy.AllowAttrs("height", "width").Matching(bluemonday.NumberOrPercent).OnElements("img")
+			src := policy.AllowAttrs("src")
+			if len(p.imageHosts) > 0 {
+				src.Matching(hostsRegex(`(?:/[^/\\]|https?://(?:%s)(?::\d+)?/)`, p.imageHosts))
+			}
+			src.OnElements("img")
+		}
+
+		if len(p.iframeHosts) > 0 {
+			policy.AllowAttrs("src").Matching(hostsRegex(`https://(?:%s)/`, p.iframeHosts)).OnElements("iframe")
+			policy.AllowAttrs("height", "width").Matching(bluemonday.NumberOrPercent).OnElements("iframe")
+			policy.AllowAttrs("title").Matching(bluemonday.Paragraph).OnElements("iframe")
+			policy.AllowAttrs("frameborder").Matching(bluemonday.Integer).OnElements("iframe")
+			policy.AllowAttrs("allowfullscreen").Matching(regexp.MustCompile(`(?i)^(|allowfullscreen|true)$`)).OnElements("iframe")
+			policy.AllowAttrs("allow").Matching(regexp.MustCompile(`^[a-z\-; ]*$`)).OnElements("iframe")
+		}
+
+		p.compiled.policy = policy
+	})
+	return p.compiled.policy
+}
+
+// rule returns new policy with rule appended.
+func (p *SanitizerPolicy) rule(fn func(*bluemonday.Policy)) *SanitizerPolicy {
+	res := p.clone()
+	res.rules = append(res.rules, fn)
+	return res
+}
+
+// clone returns uncompiled copy of policy.
+func (p *SanitizerPolicy) clone() *SanitizerPolicy {
+	res := *p
+	res.rules = append(make([]func(*bluemonday.Policy), 0, len(p.rules)+1), p.rules...)
+	res.iframeHosts = append([]string(nil), p.iframeHosts...)
+	res.compiled = new(compiledSanitizer)
+	return &res
+}
+
+// hostsRegex returns case insensitive regex matching start of value with pattern
+// where %s replaced with quoted hosts alternation.
+func hostsRegex(pattern string, hosts []string) *regexp.Regexp {
+	quoted := make([]string, len(hosts))
+	for i, host := range hosts {
+		quoted[i] = regexp.QuoteMeta(strings.ToLower(host))
+	}
+	return regexp.MustCompile(`(?i)^` + strings.Replace(pattern, "%s", strings.Join(quoted, "|"), 1))
+}
+
+// allowCommonElements allow user generated content tags and attributes,
+// same as bluemonday UGCPolicy except images and url rules.
+func allowCommonElements(p *bluemonday.Policy) {
+	p.AllowStandardAttributes()
+
+	// Structure and sections
+	p.AllowElements("article", "aside", "figure", "section", "summary", "hgroup")
+	p.AllowElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowElements("br", "div", "hr", "p", "span", "wbr")
+	p.AllowAttrs("open").Matching(regexp.MustCompile(`(?i)^(|open)$`)).OnElements("details")
+	p.AllowAttrs("cite").OnElements("blockquote", "q")
+
+	// Links
+	p.AllowAttrs("href").OnElements("a", "area")
+	p.AllowAttrs("name").Matching(regexp.MustCompile(`^([\p{L}\p{N}_-]+)$`)).OnElements("map")
+	p.AllowAttrs("alt").Matching(bluemonday.Paragraph).OnElements("area")
+	p.AllowAttrs("coords").Matching(regexp.MustCompile(`^([0-9]+,)+[0-9]+$`)).OnElements("area")
+	p.AllowAttrs("rel").Matching(bluemonday.SpaceSeparatedTokens).OnElements("area")
+	p.AllowAttrs("shape").Matching(regexp.MustCompile(`(?i)^(default|circle|rect|poly)$`)).OnElements("area")
+	p.AllowAttrs("usemap").Matching(regexp.MustCompile(`(?i)^#[\p{L}\p{N}_-]+$`)).OnElements("img")
+
+	// Phrasing
+	p.AllowElements("abbr", "acronym", "cite", "code", "dfn", "em", "figcaption", "mark",
+		"s", "samp", "strong", "sub", "sup", "var", "b", "i", "pre", "small", "strike", "tt", "u",
+		"rp", "rt", "ruby")
+	p.AllowAttrs("datetime").Matching(bluemonday.ISO8601).OnElements("time", "del", "ins")
+	p.AllowAttrs("cite").Matching(bluemonday.Paragraph).OnElements("del", "ins")
+	p.AllowAttrs("dir").Matching(bluemonday.Direction).OnElements("bdi", "bdo")
+
+	// Lists, tables and forms output
+	p.AllowLists()
+	p.AllowTables()
+	p.AllowAttrs("value", "min", "max", "low", "high", "optimum").Matching(bluemonday.Number).OnElements("meter")
+	p.AllowAttrs("value", "max").Matching(bluemonday.Number).OnElements("progress")
+}
+
+// SanitizeStruct sanitize string fields of struct pointer by `sanitize` tag rules
+// and returns path of changed fields (e.g. Profile.Bio, Tags[0] and Meta[key]).
+// Rules applied in order, available rules are raw (plain text, see SanitizeRaw),
+// escape (strip tags and keep entities escaped for html output), trim, html, slug,
+// persian (normalize characters) and digits (to ascii).
+// Nested structs, slices, maps, interfaces and pointers are walked, tag rules of slice, map and pointer
+// fields applied to contained strings. Changed *string fields replaced with new pointer. Use "-" tag to skip field.
+//
+// code block:
+//
+//	type Comment struct {
+//		Author string   `sanitize:"raw,trim"`
+//		Body   *string  `sanitize:"html"`
+//		Tags   []string `sanitize:"slug"`
+//	}
+//	changed, err := SanitizeStruct(&comment)
+//
+// output:
+//
+//	[Author Body Tags[0]]
+func SanitizeStruct(ptr any) ([]string, error) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sanitize struct: expected non-nil struct pointer, got %T", ptr)
+	}
+
+	s := &structSanitizer{visited: make(map[uintptr]bool)}
+	s.visited[v.Pointer()] = true
+	if err := s.walk(v.Elem(), "", nil); err != nil {
+		return nil, err
+	}
+	return s.changed, nil
+}
+
+// structSanitizer walk values and record changed paths.
+type structSanitizer struct {
+	changed []string
+	visited map[uintptr]bool
+}
+
+// walk apply rules to strings of value and walk nested values.
+func (s *structSanitizer) walk(v reflect.Value, path string, rules []func(string) string) error {
+	switch v.Kind() {
+	case reflect.String:
+		if len(rules) > 0 && v.CanSet() {
+			s.update(path, v.String(), rules, func(clean string) { v.SetString(clean) })
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		if ptr, ok := v.Interface().(*string); ok {
+			if len(rules) > 0 && v.CanSet() {
+				s.update(path, SafeValue(ptr), rules, func(clean string) { v.Set(reflect.ValueOf(PointerOf(clean))) })
+			}
+			return nil
+		}
+		if s.visited[v.Pointer()] {
+			return nil
+		}
+		s.visited[v.Pointer()] = true
+		return s.walk(v.Elem(), path, rules)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("sanitize")
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+
+			fieldPath := field.Name
+			if path != "" {
+				fieldPath = path + "." + field.Name
+			}
+			fieldRules, err := parseSanitizeRules(tag)
+			if err != nil {
+				return fmt.Errorf("sanitize %s: %w", fieldPath, err)
+			}
+			if err := s.walk(v.Field(i), fieldPath, fieldRules); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := s.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), rules); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, walk copy and store it back if changed
+			item := reflect.New(iter.Value().Type()).Elem()
+			item.Set(iter.Value())
+			changed := len(s.changed)
+			if err := s.walk(item, fmt.Sprintf("%s[%v]", path, iter.Key()), rules); err != nil {
+				return err
+			}
+			if len(s.changed) > changed {
+				v.SetMapIndex(iter.Key(), item)
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return nil
+		}
+		item := reflect.New(v.Elem().Type()).Elem()
+		item.Set(v.Elem())
+		changed := len(s.changed)
+		if err := s.walk(item, path, rules); err != nil {
+			return err
+		}
+		if len(s.changed) > changed {
+			v.Set(item)
+		}
+	}
+	return nil
+}
+
+// update apply rules to value and call set if value changed.
+func (s *structSanitizer) update(path, value string, rules []func(string) string, set func(string)) {
+	clean := value
+	for _, rule := range rules {
+		clean = rule(clean)
+	}
+	if clean != value {
+		set(clean)
+		s.changed = append(s.changed, path)
+	}
+}
+
+// parseSanitizeRules parse comma separated sanitize tag rules.
+func parseSanitizeRules(tag string) ([]func(string) string, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	var res []func(string) string
+	for _, name := range strings.Split(tag, ",") {
+		rule, ok := sanitizeRules[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown sanitize rule %q", name)
+		}
+		res = append(res, rule)
+	}
+	return res, nil
+}
@@ -0,0 +1,361 @@
+package goutils_test
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mekramy/goutils"
+	"golang.org/x/net/html"
+)
+
+func TestSanitizerPolicy(t *testing.T) {
+	custom := goutils.NewSanitizerPolicy().
+		AllowElements("p", "b").
+		AllowAttrs("href", "a").
+		AllowAttrsMatching("class", regexp.MustCompile(`^note$`), "p")
+
+	tests := []struct {
+		name     string
+		policy   *goutils.SanitizerPolicy
+		input    string
+		expected string
+	}{
+		{"raw", goutils.RawSanitizer, `<p>Hello <b>World</b></p>`, `Hello World`},
+		{"common", goutils.CommonSanitizer, `<p onclick="x()">Hi <a href="https://example.com">link</a></p>`, `<p>Hi <a href="https://example.com" rel="nofollow">link</a></p>`},
+		{"custom tags", custom, `<p class="note">a <b>b</b> <i>c</i></p>`, `<p class="note">a <b>b</b> c</p>`},
+		{"custom attr regex", custom, `<p class="other">a</p>`, `<p>a</p>`},
+		{"custom link", custom, `<a href="/docs">docs</a> <a href="javascript:alert(1)">x</a>`, `<a href="/docs">docs</a> x`},
+		{
+			"target blank",
+			goutils.CommonSanitizer.TargetBlank(true),
+			`<a href="https://example.com">link</a>`,
+			`<a href="https://example.com" rel="nofollow noopener" target="_blank">link</a>`,
+		},
+		{"no follow disabled", goutils.CommonSanitizer.NoFollow(false), `<a href="https://example.com">link</a>`, `<a href="https://example.com">link</a>`},
+		{"schemes", goutils.CommonSanitizer.NoFollow(false).AllowURLSchemes("https"), `<a href="mailto:a@b.com">a</a><a href="tel:123">b</a>`, `ab`},
+		{"schemes tel", goutils.CommonSanitizer.NoFollow(false).AllowURLSchemes("tel"), `<a href="tel:123">b</a>`, `<a href="tel:123">b</a>`},
+		{
+			"youtube",
+			goutils.CommonSanitizer.AllowYouTube(),
+			`<iframe src="https://www.youtube.com/embed/abc" width="560" allowfullscreen onload="x()"></iframe>`,
+			`<iframe src="https://www.youtube.com/embed/abc" width="560" allowfullscreen=""></iframe>`,
+		},
+		{"iframe other host", goutils.CommonSanitizer.AllowYouTube(), `<iframe src="https://evil.com/embed/abc"></iframe>`, ``},
+		{"iframe http", goutils.CommonSanitizer.AllowAparat(), `<iframe src="http://www.aparat.com/video/abc"></iframe>`, ``},
+		{
+			"aparat",
+			goutils.CommonSanitizer.AllowAparat(),
+			`<iframe src="https://www.aparat.com/video/video/embed/videohash/abc/vt/frame"></iframe>`,
+			`<iframe src="https://www.aparat.com/video/video/embed/videohash/abc/vt/frame"></iframe>`,
+		},
+		{"iframe not allowed", goutils.CommonSanitizer, `<iframe src="https://www.youtube.com/embed/abc"></iframe>`, ``},
+		{
+			"image hosts",
+			goutils.CommonSanitizer.AllowImages("cdn.example.com"),
+			`<img src="https://cdn.example.com/a.png" alt="a"><img src="https://evil.com/a.png"><img src="/local.png">`,
+			`<img src="https://cdn.example.com/a.png" alt="a"><img src="/local.png">`,
+		},
+		{"image host prefix", goutils.CommonSanitizer.AllowImages("cdn.example.com"), `<img src="https://cdn.example.com.evil.com/a.png"><img src="//evil.com/a.png">`, ``},
+		{"images any host", goutils.CommonSanitizer, `<img src="https://any.com/a.png">`, `<img src="https://any.com/a.png">`},
+		{"images disabled", goutils.RawSanitizer, `<img src="https://any.com/a.png">`, ``},
+	}
+
+	for _, test := range tests {
+		if result := test.policy.Sanitize(test.input); result != test.expected {
+			t.Errorf("%s: Sanitize(%q) = %q; want %q", test.name, test.input, result, test.expected)
+		}
+	}
+}
+
+func TestSanitizerPolicyImmutable(t *testing.T) {
+	base := goutils.CommonSanitizer
+	_ = base.AllowYouTube().TargetBlank(true).AllowImages("cdn.example.com")
+
+	input := `<a href="https://example.com">a</a><img src="https://any.com/a.png"><iframe src="https://www.youtube.com/embed/x"></iframe>`
+	expected := `<a href="https://example.com" rel="nofollow">a</a><img src="https://any.com/a.png">`
+	if result := base.Sanitize(input); result != expected {
+		t.Errorf("Sanitize(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+func TestSanitizerPolicyConcurrent(t *testing.T) {
+	policy := goutils.CommonSanitizer.AllowYouTube()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if result := policy.Sanitize(`<b onclick="x">bold</b>`); result != `<b>bold</b>` {
+				t.Errorf("Sanitize() = %q; want %q", result, `<b>bold</b>`)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// xssCorpus contains common xss vectors.
+var xssCorpus = []string{
+	`<script>alert(1)</script>`,
+	`&lt;script&gt;alert(1)&lt;/script&gt;`,
+	`&amp;lt;script&amp;gt;alert(1)&amp;lt;/script&amp;gt;`,
+	`<img src=x onerror=alert(1)>`,
+	`<img src="javascript:alert(1)">`,
+	`<a href="javascript:alert(1)">x</a>`,
+	`<a href="JaVaScRiPt:alert(1)">x</a>`,
+	`<a href="&#106;avascript:alert(1)">x</a>`,
+	`<a href="java&#x09;script:alert(1)">x</a>`,
+	`<a href=" javascript:alert(1)">x</a>`,
+	`<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`,
+	`<a href="vbscript:msgbox(1)">x</a>`,
+	`<svg onload=alert(1)>`,
+	`<svg><script>alert(1)</script></svg>`,
+	`<math><mi xlink:href="javascript:alert(1)">x</mi></math>`,
+	`<iframe src="javascript:alert(1)"></iframe>`,
+	`<iframe srcdoc="<script>alert(1)</script>"></iframe>`,
+	`<body onload=alert(1)>`,
+	`<div style="background:url(javascript:alert(1))">x</div>`,
+	`<p title="&quot;><script>alert(1)</script>">x</p>`,
+	`<<script>script>alert(1)<</script>/script>`,
+	`<scr<script>ipt>alert(1)</scr</script>ipt>`,
+	`<object data="javascript:alert(1)"></object>`,
+	`<embed src="javascript:alert(1)">`,
+	`<form action="javascript:alert(1)"><input type=submit></form>`,
+	`<meta http-equiv="refresh" content="0;url=javascript:alert(1)">`,
+	`<base href="javascript:alert(1)//">`,
+	`<link rel=stylesheet href="javascript:alert(1)">`,
+	`<details open ontoggle=alert(1)>`,
+	`<a href="#" onclick="alert(1)">x</a>`,
+	`<!--<script>alert(1)</script>-->`,
+	`<![CDATA[<script>alert(1)</script>]]>`,
+	`<textarea><script>alert(1)</script></textarea>`,
+	`<noscript><p title="</noscript><img src=x onerror=alert(1)>">`,
+	`<style><img src=x onerror=alert(1)></style>`,
+	"<a href=\"java\x00script:alert(1)\">x</a>",
+	`<img src="x" alt="&lt;img src=x onerror=alert(1)&gt;">`,
+	`<p>Tom &amp; Jerry &lt;3</p>`,
+}
+
+// unsafeTags contains tags must never appear in sanitized html.
+var unsafeTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "svg": true, "math": true,
+	"form": true, "input": true, "textarea": true, "base": true, "meta": true, "link": true, "body": true, "noscript": true,
+}
+
+// assertSafeHTML parse html and check it has no unsafe tags, event handlers or script urls.
+func assertSafeHTML(t *testing.T, input, output string) {
+	t.Helper()
+	tokenizer := html.NewTokenizer(strings.NewReader(output))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			token := tokenizer.Token()
+			if unsafeTags[token.Data] {
+				t.Fatalf("sanitize(%q) = %q; contains <%s>", input, output, token.Data)
+			}
+			for _, attr := range token.Attr {
+				value := strings.ToLower(strings.Map(func(r rune) rune {
+					if r <= ' ' {
+						return -1
+					}
+					return r
+				}, attr.Val))
+				if strings.HasPrefix(attr.Key, "on") || attr.Key == "style" || attr.Key == "srcdoc" ||
+					strings.HasPrefix(value, "javascript:") || strings.HasPrefix(value, "vbscript:") ||
+					(strings.HasPrefix(value, "data:") && attr.Key != "alt" && attr.Key != "title") {
+					t.Fatalf("sanitize(%q) = %q; contains unsafe attribute %s=%q", input, output, attr.Key, attr.Val)
+				}
+			}
+		}
+	}
+}
+
+// assertPlainHTML check escaped plain text has no html tags.
+func assertPlainHTML(t *testing.T, input, output string) {
+	t.Helper()
+	tokenizer := html.NewTokenizer(strings.NewReader(output))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return
+		case html.TextToken:
+		default:
+			t.Fatalf("SanitizeText(%q).HTML() = %q; contains markup", input, output)
+		}
+	}
+}
+
+func TestSanitizeXSSCorpus(t *testing.T) {
+	for _, input := range xssCorpus {
+		safe := goutils.SanitizeHTML(input)
+		assertSafeHTML(t, input, safe.String())
+		assertSafeHTML(t, input, goutils.SanitizeCommon(input, true))
+		assertPlainHTML(t, input, goutils.SanitizeText(input).HTML().String())
+
+		// Sanitizing sanitized html must not reintroduce markup
+		if again := goutils.SanitizeHTML(safe.String()); again != safe {
+			t.Errorf("SanitizeHTML(SanitizeHTML(%q)) = %q; want %q", input, again, safe)
+		}
+	}
+}
+
+func TestSanitizeHTMLKeepsEntities(t *testing.T) {
+	tests := []struct {
+		input string
+		html  goutils.SafeHTML
+		text  goutils.PlainText
+	}{
+		{`&lt;script&gt;alert(1)&lt;/script&gt;`, `&lt;script&gt;alert(1)&lt;/script&gt;`, `<script>alert(1)</script>`},
+		{`<b>Tom &amp; Jerry</b> &lt;3`, `<b>Tom &amp; Jerry</b> &lt;3`, `Tom & Jerry <3`},
+		{`<p>"quoted"</p>`, `<p>&#34;quoted&#34;</p>`, `"quoted"`},
+	}
+
+	for _, test := range tests {
+		if result := goutils.SanitizeHTML(test.input); result != test.html {
+			t.Errorf("SanitizeHTML(%q) = %q; want %q", test.input, result, test.html)
+		}
+		if result := goutils.SanitizeText(test.input); result != test.text {
+			t.Errorf("SanitizeText(%q) = %q; want %q", test.input, result, test.text)
+		}
+	}
+
+	if result := goutils.PlainText(`<b>&`).HTML(); result != `&lt;b&gt;&amp;` {
+		t.Errorf("PlainText.HTML() = %q; want %q", result, `&lt;b&gt;&amp;`)
+	}
+}
+
+func FuzzSanitizeHTML(f *testing.F) {
+	for _, input := range xssCorpus {
+		f.Add(input)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		safe := goutils.SanitizeHTML(input)
+		assertSafeHTML(t, input, safe.String())
+		assertPlainHTML(t, input, goutils.SanitizeText(input).HTML().String())
+		if again := goutils.SanitizeHTML(safe.String()); again != safe {
+			t.Errorf("SanitizeHTML(SanitizeHTML(%q)) = %q; want %q", input, again, safe)
+		}
+	})
+}
+
+func TestSanitizeStruct(t *testing.T) {
+	type Address struct {
+		City   string `sanitize:"persian,trim"`
+		Postal string `sanitize:"digits"`
+	}
+	type Comment struct {
+		Author   string            `sanitize:"raw,trim"`
+		Body     *string           `sanitize:"html"`
+		Slug     string            `sanitize:"slug"`
+		Tags     []string          `sanitize:"trim"`
+		Meta     map[string]string `sanitize:"raw"`
+		Note     string            `sanitize:"raw"`
+		Escaped  string            `sanitize:"escape"`
+		Address  Address
+		Previous *Address
+		Others   []Address
+		Extra    any     `sanitize:"trim"`
+		Empty    *string `sanitize:"trim"`
+		Skip     string  `sanitize:"-"`
+		Plain    string
+		private  string
+	}
+
+	body := `<b>Hi</b><script>alert(1)</script>`
+	comment := Comment{
+		Author:   "  <i>John</i> ",
+		Body:     &body,
+		Slug:     "Hello World",
+		Tags:     []string{" go ", "web"},
+		Meta:     map[string]string{"a": "<b>x</b>", "b": "y"},
+		Note:     "<b>Tom &amp; Jerry</b> &lt;3",
+		Escaped:  "<b>Tom & Jerry</b> &lt;script&gt;alert(1)&lt;/script&gt;",
+		Address:  Address{City: " تهران ", Postal: "۱۲۳۴۵"},
+		Previous: &Address{City: "كرج"},
+		Others:   []Address{{Postal: "1"}, {Postal: "٢"}},
+		Extra:    " extra ",
+		Skip:     " <b>skip</b> ",
+		Plain:    " <b>plain</b> ",
+		private:  " <b>private</b> ",
+	}
+
+	changed, err := goutils.SanitizeStruct(&comment)
+	if err != nil {
+		t.Fatalf("SanitizeStruct() error = %v", err)
+	}
+
+	expectedChanged := []string{"Author", "Body", "Slug", "Tags[0]", "Meta[a]", "Note", "Escaped", "Address.City", "Address.Postal", "Previous.City", "Others[1].Postal", "Extra"}
+	if strings.Join(changed, ",") != strings.Join(expectedChanged, ",") {
+		t.Errorf("SanitizeStruct() changed = %q; want %q", changed, expectedChanged)
+	}
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{"Author", comment.Author, "John"},
+		{"Body", *comment.Body, "<b>Hi</b>"},
+		{"Slug", comment.Slug, "Hello-World"},
+		{"Tags[0]", comment.Tags[0], "go"},
+		{"Meta[a]", comment.Meta["a"], "x"},
+		{"Note", comment.Note, "Tom & Jerry <3"},
+		{"Escaped", comment.Escaped, "Tom &amp; Jerry &lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"Address.City", comment.Address.City, "تهران"},
+		{"Address.Postal", comment.Address.Postal, "12345"},
+		{"Previous.City", comment.Previous.City, "کرج"},
+		{"Others[1].Postal", comment.Others[1].Postal, "2"},
+		{"Extra", comment.Extra.(string), "extra"},
+		{"Skip", comment.Skip, " <b>skip</b> "},
+		{"Plain", comment.Plain, " <b>plain</b> "},
+		{"private", comment.private, " <b>private</b> "},
+	}
+	for _, test := range tests {
+		if test.result != test.expected {
+			t.Errorf("SanitizeStruct() %s = %q; want %q", test.name, test.result, test.expected)
+		}
+	}
+
+	// Escaped output is raw output escaped for html
+	if escaped := goutils.PlainText(comment.Note).HTML().String(); escaped != "Tom &amp; Jerry &lt;3" {
+		t.Errorf("PlainText(%q).HTML() = %q; want %q", comment.Note, escaped, "Tom &amp; Jerry &lt;3")
+	}
+
+	// Changed string pointers are replaced, not written through
+	if body != `<b>Hi</b><script>alert(1)</script>` {
+		t.Errorf("SanitizeStruct() modified original string pointer value to %q", body)
+	}
+	if comment.Empty != nil {
+		t.Errorf("SanitizeStruct() Empty = %v; want nil", comment.Empty)
+	}
+}
+
+func TestSanitizeStructErrors(t *testing.T) {
+	type Invalid struct {
+		Name string `sanitize:"trim,unknown"`
+	}
+	type Node struct {
+		Name string `sanitize:"trim"`
+		Next *Node
+	}
+
+	if _, err := goutils.SanitizeStruct(&Invalid{}); err == nil || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("SanitizeStruct() with unknown rule error = %v; want error with field name", err)
+	}
+	for _, input := range []any{nil, Node{}, (*Node)(nil), new(string)} {
+		if _, err := goutils.SanitizeStruct(input); err == nil {
+			t.Errorf("SanitizeStruct(%T) expected error", input)
+		}
+	}
+
+	// Cyclic pointers must not loop forever
+	node := &Node{Name: " a "}
+	node.Next = node
+	if changed, err := goutils.SanitizeStruct(node); err != nil || len(changed) != 1 || node.Name != "a" {
+		t.Errorf("SanitizeStruct() cyclic = %q, %v; want [Name]", changed, err)
+	}
+}
@@ -0,0 +1,185 @@
+package goutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FileEntry represents file state in directory snapshot.
+type FileEntry struct {
+	Path    string      `json:"path"`
+	Size    int64       `json:"size"`
+	Mode    os.FileMode `json:"mode"`
+	ModTime time.Time   `json:"mod_time"`
+	Hash    string      `json:"hash,omitempty"`
+}
+
+// DirSnapshot represents state of directory files.
+// File paths are relative to root and slash separated.
+type DirSnapshot struct {
+	Root      string               `json:"root"`
+	CreatedAt time.Time            `json:"created_at"`
+	Files     map[string]FileEntry `json:"files"`
+}
+
+// RenamedFile represents file moved to new path with same content.
+type RenamedFile struct {
+	From FileEntry `json:"from"`
+	To   FileEntry `json:"to"`
+}
+
+// SnapshotDiff represents changes between two directory snapshots.
+type SnapshotDiff struct {
+	Added    []FileEntry   `json:"added"`
+	Removed  []FileEntry   `json:"removed"`
+	Modified []FileEntry   `json:"modified"`
+	Renamed  []RenamedFile `json:"renamed"`
+}
+
+// Snapshot record state of directory files with name matching pattern.
+// Pass empty pattern to include all files and hash to store sha256 of file contents.
+func Snapshot(dir, pattern string, hash bool) (*DirSnapshot, error) {
+	snapshot := &DirSnapshot{
+		Root:      NormalizePath(dir),
+		CreatedAt: time.Now(),
+		Files:     make(map[string]FileEntry),
+	}
+
+	err := walkMatchedFiles(dir, pattern, func(path string, info os.FileInfo) error {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		entry := FileEntry{
+			Path:    filepath.ToSlash(rel),
+			Size:    info.Size(),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		}
+		if hash {
+			if entry.Hash, err = hashFile(path); err != nil {
+				return err
+			}
+		}
+
+		snapshot.Files[entry.Path] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// LoadSnapshot read json encoded snapshot from file.
+func LoadSnapshot(path string) (*DirSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := new(DirSnapshot)
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Save write json encoded snapshot to file.
+func (s *DirSnapshot) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// CompareSnapshots returns changes from prev to next snapshot.
+// Removed and added files with same hash reported as renamed.
+func CompareSnapshots(prev, next *DirSnapshot) *SnapshotDiff {
+	diff := new(SnapshotDiff)
+	for _, path := range sortedPaths(next.Files) {
+		entry := next.Files[path]
+		old, ok := prev.Files[path]
+		if !ok {
+			diff.Added = append(diff.Added, entry)
+		} else if isFileModified(old, entry) {
+			diff.Modified = append(diff.Modified, entry)
+		}
+	}
+	for _, path := range sortedPaths(prev.Files) {
+		if _, ok := next.Files[path]; !ok {
+			diff.Removed = append(diff.Removed, prev.Files[path])
+		}
+	}
+
+	// Detect renamed files by hash
+	var added []FileEntry
+	for _, entry := range diff.Added {
+		idx := -1
+		for i, removed := range diff.Removed {
+			if entry.Hash != "" && entry.Hash == removed.Hash && entry.Size == removed.Size {
+				idx = i
+				break
+			}
+		}
+
+		if idx < 0 {
+			added = append(added, entry)
+			continue
+		}
+		diff.Renamed = append(diff.Renamed, RenamedFile{From: diff.Removed[idx], To: entry})
+		diff.Removed = append(diff.Removed[:idx], diff.Removed[idx+1:]...)
+	}
+	diff.Added = added
+
+	return diff
+}
+
+// HasChanges check if diff contains any change.
+func (d *SnapshotDiff) HasChanges() bool {
+	return len(d.Added)+len(d.Removed)+len(d.Modified)+len(d.Renamed) > 0
+}
+
+// isFileModified compare content hash if both entries hashed, otherwise size and modification time.
+func isFileModified(old, entry FileEntry) bool {
+	if old.Mode != entry.Mode {
+		return true
+	}
+	if old.Hash != "" && entry.Hash != "" {
+		return old.Hash != entry.Hash
+	}
+	return old.Size != entry.Size || !old.ModTime.Equal(entry.ModTime)
+}
+
+// sortedPaths returns sorted keys of files map.
+func sortedPaths(files map[string]FileEntry) []string {
+	res := make([]string, 0, len(files))
+	for path := range files {
+		res = append(res, path)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// hashFile returns hex encoded sha256 of file content.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,81 @@
+package goutils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "sub"), os.ModePerm)
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("A"), 0644)
+	os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("BB"), 0644)
+	os.WriteFile(filepath.Join(dir, "c.log"), []byte("C"), 0644)
+
+	snapshot, err := goutils.Snapshot(dir, `\.txt$`, true)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+	if len(snapshot.Files) != 2 {
+		t.Errorf("expected 2 files, got %d", len(snapshot.Files))
+	}
+	entry, ok := snapshot.Files["sub/b.txt"]
+	if !ok || entry.Size != 2 || entry.Hash == "" {
+		t.Errorf("unexpected entry for sub/b.txt: %+v", entry)
+	}
+
+	// Save and load
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := snapshot.Save(file); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	loaded, err := goutils.LoadSnapshot(file)
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %v", err)
+	}
+	if goutils.CompareSnapshots(snapshot, loaded).HasChanges() {
+		t.Errorf("expected loaded snapshot to be equal")
+	}
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("KEEP"), 0644)
+	os.WriteFile(filepath.Join(dir, "edit.txt"), []byte("OLD"), 0644)
+	os.WriteFile(filepath.Join(dir, "delete.txt"), []byte("DELETE"), 0644)
+	os.WriteFile(filepath.Join(dir, "move.txt"), []byte("MOVE"), 0644)
+
+	prev, err := goutils.Snapshot(dir, "", true)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "edit.txt"), []byte("NEW CONTENT"), 0644)
+	os.Chtimes(filepath.Join(dir, "keep.txt"), time.Now(), time.Now().Add(time.Hour))
+	os.Remove(filepath.Join(dir, "delete.txt"))
+	os.Rename(filepath.Join(dir, "move.txt"), filepath.Join(dir, "moved.txt"))
+	os.WriteFile(filepath.Join(dir, "add.txt"), []byte("ADD"), 0644)
+
+	next, err := goutils.Snapshot(dir, "", true)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	diff := goutils.CompareSnapshots(prev, next)
+	if len(diff.Added) != 1 || diff.Added[0].Path != "add.txt" {
+		t.Errorf("expected add.txt added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Path != "delete.txt" {
+		t.Errorf("expected delete.txt removed, got %+v", diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].Path != "edit.txt" {
+		t.Errorf("expected edit.txt modified, got %+v", diff.Modified)
+	}
+	if len(diff.Renamed) != 1 || diff.Renamed[0].From.Path != "move.txt" || diff.Renamed[0].To.Path != "moved.txt" {
+		t.Errorf("expected move.txt renamed to moved.txt, got %+v", diff.Renamed)
+	}
+}
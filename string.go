@@ -1,71 +1,373 @@
 package goutils
 
 import (
-	"regexp"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
-	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"golang.org/x/exp/rand"
 	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 )
 
 // ExtractNumbers extract numbers from string.
 func ExtractNumbers(s string) string {
-	rx := regexp.MustCompile(`[^0-9]`)
-	return rx.ReplaceAllString(s, "")
+	return strings.Map(func(r rune) rune {
+		if isDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// ExtractLocalizedNumbers extract numbers from string
+// and translate persian and arabic-indic digits to ascii digits.
+func ExtractLocalizedNumbers(s string) string {
+	return ExtractNumbers(ToEnglishDigits(s))
+}
+
+// AlphaNumTable contains ascii letters and digits [a-zA-Z0-9].
+var AlphaNumTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: '0', Hi: '9', Stride: 1},
+		{Lo: 'A', Hi: 'Z', Stride: 1},
+		{Lo: 'a', Hi: 'z', Stride: 1},
+	},
+	LatinOffset: 3,
+}
+
+// PersianTable contains persian and arabic characters [\u0600-\u06FF] and persian presentation forms.
+var PersianTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x06FF, Stride: 1},
+		{Lo: 0xFB8A, Hi: 0xFB8A, Stride: 1},
+	},
+}
+
+// ExtractRunes keep runes that exists in allowed string or belong to one of tables.
+//
+// code block:
+//
+//	ExtractRunes("Hello, [World]! 123", "[]", unicode.Latin)
+//
+// output:
+//
+//	Hello[World]
+func ExtractRunes(s string, allowed string, tables ...*unicode.RangeTable) string {
+	return filterRunes(s, func(r rune) bool {
+		return strings.ContainsRune(allowed, r) || unicode.IsOneOf(tables, r)
+	})
 }
 
 // ExtractAlphaNum extract alpha and numbers from string [a-zA-Z0-9].
+// includes characters are kept literally (e.g. "-_" keep dash and underscore).
+// includes were regex character class syntax in previous versions,
+// use ExtractAlphaNumRx for regex syntax (e.g. `\s\-`).
 func ExtractAlphaNum(s string, includes ...string) string {
-	pattern := "[^a-zA-Z0-9" + strings.Join(includes, "") + "]"
-	rx := regexp.MustCompile(pattern)
-	return rx.ReplaceAllString(s, "")
+	allowed := strings.Join(includes, "")
+	return filterRunes(s, func(r rune) bool {
+		return isAlphaNum(r) || strings.ContainsRune(allowed, r)
+	})
 }
 
 // ExtractAlphaNumPersian extract english and persian alpha and numbers from string [ا-یa-zA-Z0-9].
+// includes characters are kept literally (e.g. "-_" keep dash and underscore).
+// includes were regex character class syntax in previous versions,
+// use ExtractAlphaNumPersianRx for regex syntax (e.g. `\s\-`).
 func ExtractAlphaNumPersian(s string, includes ...string) string {
-	pattern := "[^\u0600-\u06FF\uFB8A\u067E\u0686\u06AFa-zA-Z0-9" + strings.Join(includes, "") + "]"
-	rx := regexp.MustCompile(pattern)
-	return rx.ReplaceAllString(s, "")
+	allowed := strings.Join(includes, "")
+	return filterRunes(s, func(r rune) bool {
+		return isPersianAlphaNum(r) || strings.ContainsRune(allowed, r)
+	})
 }
 
-// RandomNumeric returns random string from character set.
+// ExtractAlphaNumRx extract alpha and numbers from string [a-zA-Z0-9].
+// includes are regex character class syntax (e.g. `\s\-`).
+func ExtractAlphaNumRx(s string, includes ...string) (string, error) {
+	rx, err := compileRegex("[^a-zA-Z0-9" + strings.Join(includes, "") + "]")
+	if err != nil {
+		return "", err
+	}
+	return rx.ReplaceAllString(s, ""), nil
+}
+
+// ExtractAlphaNumPersianRx extract english and persian alpha and numbers from string [ا-یa-zA-Z0-9].
+// includes are regex character class syntax (e.g. `\s\-`).
+func ExtractAlphaNumPersianRx(s string, includes ...string) (string, error) {
+	rx, err := compileRegex("[^\u0600-\u06FF\uFB8A\u067E\u0686\u06AFa-zA-Z0-9" + strings.Join(includes, "") + "]")
+	if err != nil {
+		return "", err
+	}
+	return rx.ReplaceAllString(s, ""), nil
+}
+
+// Character set presets for random generators.
+const (
+	CharsLower     = "abcdefghijklmnopqrstuvwxyz"
+	CharsUpper     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CharsDigits    = "0123456789"
+	CharsSymbols   = "!@#$%^&*()-_=+[]{}<>?"
+	CharsPersian   = "ابپتثجچحخدذرزژسشصضطظعغفقکگلمنوهی"
+	CharsLookAlike = "0O1lI"
+)
+
+// RandomSource represents a source of random bytes for random generators.
+// crypto/rand.Reader is used by default, tests can inject a deterministic reader.
+type RandomSource interface {
+	Read(p []byte) (n int, err error)
+}
+
+// ExcludeLookAlike remove look-alike characters (0/O/1/l/I) from character set.
+func ExcludeLookAlike(characters string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(CharsLookAlike, r) {
+			return -1
+		}
+		return r
+	}, characters)
+}
+
+// RandomString returns cryptographically secure random string from character set.
+// Character set can contains multi-byte characters.
 func RandomString(n uint, characters string) string {
-	src := rand.NewSource(uint64(time.Now().UnixNano()))
-	rnd := rand.New(src)
+	res, err := RandomStringFrom(rand.Reader, n, characters)
+	if err != nil {
+		return ""
+	}
+	return res
+}
+
+// RandomStringFrom returns random string from character set using src as random source.
+func RandomStringFrom(src RandomSource, n uint, characters string) (string, error) {
+	chars := uniqueRunes(characters)
+	if len(chars) == 0 {
+		return "", errors.New("empty character set")
+	}
 
-	result := make([]byte, n)
+	result := make([]rune, n)
 	for i := range result {
-		result[i] = characters[rnd.Intn(len(characters))]
+		idx, err := randomIndex(src, len(chars))
+		if err != nil {
+			return "", err
+		}
+		result[i] = chars[idx]
+	}
+	return string(result), nil
+}
+
+// RandomPolicyString returns random string contains at least one character from each class.
+//
+// code block:
+//
+//	RandomPolicyString(12, CharsLower, CharsUpper, CharsDigits, CharsSymbols)
+func RandomPolicyString(n uint, classes ...string) (string, error) {
+	return RandomPolicyStringFrom(rand.Reader, n, classes...)
+}
+
+// RandomPolicyStringFrom returns random string contains at least one character
+// from each class using src as random source.
+func RandomPolicyStringFrom(src RandomSource, n uint, classes ...string) (string, error) {
+	if int(n) < len(classes) {
+		return "", errors.New("length is less than classes count")
+	}
+
+	// Pick one character from each class
+	result := make([]rune, 0, n)
+	for _, class := range classes {
+		part, err := RandomStringFrom(src, 1, class)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, []rune(part)...)
+	}
+
+	// Fill rest from all classes
+	rest, err := RandomStringFrom(src, n-uint(len(classes)), strings.Join(classes, ""))
+	if err != nil {
+		return "", err
+	}
+	result = append(result, []rune(rest)...)
+
+	// Shuffle result
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := randomIndex(src, i+1)
+		if err != nil {
+			return "", err
+		}
+		result[i], result[j] = result[j], result[i]
+	}
+	return string(result), nil
+}
+
+// uniqueRunes returns unique runes of string in order.
+func uniqueRunes(s string) []rune {
+	seen := make(map[rune]struct{})
+	res := make([]rune, 0, len(s))
+	for _, r := range s {
+		if _, ok := seen[r]; !ok {
+			seen[r] = struct{}{}
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// randomIndex returns uniform random number in [0, max) using rejection sampling
+// to prevent modulo bias.
+func randomIndex(src RandomSource, max int) (int, error) {
+	if max <= 0 {
+		return 0, errors.New("invalid random range")
+	}
+
+	buf := make([]byte, 8)
+	limit := math.MaxUint64 - math.MaxUint64%uint64(max)
+	for {
+		if _, err := io.ReadFull(src, buf); err != nil {
+			return 0, err
+		}
+		if v := binary.BigEndian.Uint64(buf); v < limit {
+			return int(v % uint64(max)), nil
+		}
 	}
-	return string(result)
 }
 
 // RandomNumeric returns random numeric string.
 func RandomNumeric(n uint) string {
-	return RandomString(n, "0123456789")
+	return RandomString(n, CharsDigits)
 }
 
 // RandomAlphaNum returns random string from Alpha-Num uppercase characters.
 func RandomAlphaNum(n uint) string {
-	return RandomString(n, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	return RandomString(n, CharsUpper+CharsDigits)
 }
 
 // Slugify make url friendly slug from strings.
 // Only Alpha-Num characters compiled to final result.
 func Slugify(parts ...string) string {
-	normalized := ExtractAlphaNum(strings.Join(parts, " "), `\s\-`)
-	rx := regexp.MustCompile(`[\s\-]+`)
-	return rx.ReplaceAllString(normalized, "-")
+	return slugify(strings.Join(parts, " "), isAlphaNum)
 }
 
 // SlugifyPersian make url friendly slug from strings.
 // Only english and persian alpha and numberic characters compiled to final result.
 func SlugifyPersian(parts ...string) string {
-	normalized := ExtractAlphaNumPersian(strings.Join(parts, " "), `\s\-`)
-	rx := regexp.MustCompile(`[\s\-]+`)
-	return rx.ReplaceAllString(normalized, "-")
+	return slugify(strings.Join(parts, " "), isPersianAlphaNum)
+}
+
+// slugMaxAttempts is maximum number of unique slug candidates checked by SlugifyWith.
+const slugMaxAttempts = 1000
+
+// SlugOptions represents options for SlugifyWith.
+type SlugOptions struct {
+	// Separator between words, default is "-".
+	Separator string
+	// Lowercase convert slug to lowercase.
+	Lowercase bool
+	// Transliterate convert non-ascii letters to ascii (see Transliterate).
+	Transliterate bool
+	// MaxLength limit slug length in characters, slug cut on word boundary.
+	MaxLength int
+	// StopWords removed from slug (case-insensitive).
+	StopWords []string
+	// Exists check if slug already exists, -2, -3, ... appended to make slug unique.
+	// SlugifyWith returns error after 1000 attempts.
+	Exists func(slug string) (bool, error)
+}
+
+// SlugifyWith make url friendly slug from strings with options.
+// Letters and numbers of any language compiled to final result.
+//
+// code block:
+//
+//	SlugifyWith(SlugOptions{Lowercase: true, Transliterate: true}, "Café", "Münch!")
+//
+// output:
+//
+//	cafe-munch
+func SlugifyWith(options SlugOptions, parts ...string) (string, error) {
+	sep := options.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	text := strings.Join(parts, " ")
+	if options.Transliterate {
+		text = Transliterate(text)
+	}
+	if options.Lowercase {
+		text = strings.ToLower(text)
+	}
+
+	// Split words and remove stop words
+	stops := make(map[string]struct{}, len(options.StopWords))
+	for _, word := range options.StopWords {
+		stops[strings.ToLower(word)] = struct{}{}
+	}
+	words := make([]string, 0)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		if _, ok := stops[strings.ToLower(word)]; !ok {
+			words = append(words, word)
+		}
+	}
+
+	slug := limitSlug(words, sep, options.MaxLength)
+	if options.Exists == nil || slug == "" {
+		return slug, nil
+	}
+
+	// Generate unique slug
+	for i := 1; i <= slugMaxAttempts; i++ {
+		candidate := slug
+		if i > 1 {
+			suffix := sep + strconv.Itoa(i)
+			if options.MaxLength > 0 {
+				candidate = limitSlug(words, sep, Max(options.MaxLength-len([]rune(suffix)), 1))
+			}
+			candidate += suffix
+		}
+
+		exists, err := options.Exists(candidate)
+		if err != nil {
+			return "", err
+		} else if !exists {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("try %d slug failed", slugMaxAttempts)
+}
+
+// limitSlug join words with separator and limit length on word boundary.
+// First word cut if longer than max.
+func limitSlug(words []string, sep string, max int) string {
+	slug := strings.Join(words, sep)
+	if max <= 0 || len([]rune(slug)) <= max {
+		return slug
+	}
+
+	var res string
+	for i, word := range words {
+		candidate := word
+		if i > 0 {
+			candidate = res + sep + word
+		}
+		if len([]rune(candidate)) > max {
+			break
+		}
+		res = candidate
+	}
+
+	if res == "" && len(words) > 0 {
+		res = string([]rune(words[0])[:max])
+	}
+	return res
 }
 
 // Concat return concatinated not-empty strings with separator.
@@ -79,6 +381,177 @@ func Concat(sep string, parts ...string) string {
 	return strings.Join(res, sep)
 }
 
+// TruncateOptions represents options for Truncate.
+type TruncateOptions struct {
+	// Graphemes count user-perceived characters (grapheme clusters) instead of runes.
+	Graphemes bool
+	// WordBoundary cut on last whitespace before limit if possible.
+	WordBoundary bool
+	// Ellipsis appended to truncated string, counted in length limit.
+	Ellipsis string
+}
+
+// Truncate limit string length to n runes or grapheme clusters.
+// Grapheme clusters (e.g. emoji with ZWJ and skin tones or letters with
+// combining marks) never split.
+//
+// code block:
+//
+//	Truncate("Hello beautiful world", 12, TruncateOptions{WordBoundary: true, Ellipsis: "…"})
+//
+// output:
+//
+//	Hello…
+func Truncate(s string, n int, options TruncateOptions) string {
+	clusters := graphemes(s)
+	size := func(cluster string) int {
+		if options.Graphemes {
+			return 1
+		}
+		return utf8.RuneCountInString(cluster)
+	}
+
+	total := 0
+	for _, cluster := range clusters {
+		total += size(cluster)
+	}
+	if total <= n {
+		return s
+	}
+
+	ellipsis := options.Ellipsis
+	limit := n - utf8.RuneCountInString(ellipsis)
+	if options.Graphemes {
+		limit = n - len(graphemes(ellipsis))
+	}
+	if limit < 0 {
+		limit, ellipsis = n, ""
+	}
+
+	// Take clusters while fit in limit
+	count, length := 0, 0
+	for count < len(clusters) && length+size(clusters[count]) <= limit {
+		length += size(clusters[count])
+		count++
+	}
+
+	// Cut on last whitespace if word is split
+	if options.WordBoundary && count > 0 && count < len(clusters) && !isSpaceCluster(clusters[count]) {
+		for i := count - 1; i > 0; i-- {
+			if isSpaceCluster(clusters[i]) {
+				count = i
+				break
+			}
+		}
+	}
+
+	res := strings.TrimRightFunc(strings.Join(clusters[:count], ""), unicode.IsSpace)
+	return res + ellipsis
+}
+
+// DefaultInitialisms contains common initialisms used by case conversion functions.
+var DefaultInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "JWT", "OTP", "QPS", "RAM", "RPC", "SLA", "SMTP", "SQL", "SSH",
+	"TCP", "TLS", "TTL", "UDP", "UI", "UID", "URI", "URL", "UTF8", "UUID", "VM", "XML", "XSS",
+}
+
+var defaultCaseConverter = NewCaseConverter(DefaultInitialisms...)
+
+// CaseConverter convert strings between naming conventions
+// and keep configured initialisms uppercase in camel, pascal and title case.
+type CaseConverter struct {
+	initialisms map[string]struct{}
+}
+
+// NewCaseConverter create case converter with initialisms (e.g. "ID", "HTTP").
+func NewCaseConverter(initialisms ...string) *CaseConverter {
+	c := &CaseConverter{initialisms: make(map[string]struct{}, len(initialisms))}
+	for _, item := range initialisms {
+		c.initialisms[strings.ToUpper(item)] = struct{}{}
+	}
+	return c
+}
+
+// Camel convert string to camelCase (e.g. "user_id" to "userID").
+func (c *CaseConverter) Camel(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = c.capitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// Pascal convert string to PascalCase (e.g. "user_id" to "UserID").
+func (c *CaseConverter) Pascal(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = c.capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// Title convert string to Title Case (e.g. "user_id" to "User ID").
+func (c *CaseConverter) Title(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = c.capitalize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// capitalize returns uppercase initialism, plural initialism (e.g. "IDs")
+// or word with uppercase first letter.
+func (c *CaseConverter) capitalize(word string) string {
+	upper := strings.ToUpper(word)
+	if _, ok := c.initialisms[upper]; ok {
+		return upper
+	}
+	if singular, ok := strings.CutSuffix(upper, "S"); ok {
+		if _, ok := c.initialisms[singular]; ok {
+			return singular + "s"
+		}
+	}
+
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// ToCamel convert string to camelCase with default initialisms (e.g. "HTTPServerID" to "httpServerID").
+func ToCamel(s string) string {
+	return defaultCaseConverter.Camel(s)
+}
+
+// ToPascal convert string to PascalCase with default initialisms (e.g. "http_server_id" to "HTTPServerID").
+func ToPascal(s string) string {
+	return defaultCaseConverter.Pascal(s)
+}
+
+// ToTitle convert string to Title Case with default initialisms (e.g. "http_server_id" to "HTTP Server ID").
+func ToTitle(s string) string {
+	return defaultCaseConverter.Title(s)
+}
+
+// ToSnake convert string to snake_case (e.g. "HTTPServerID" to "http_server_id").
+func ToSnake(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "_"))
+}
+
+// ToKebab convert string to kebab-case (e.g. "HTTPServerID" to "http-server-id").
+func ToKebab(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "-"))
+}
+
+// ToScreamingSnake convert string to SCREAMING_SNAKE_CASE (e.g. "HTTPServerID" to "HTTP_SERVER_ID").
+func ToScreamingSnake(s string) string {
+	return strings.ToUpper(strings.Join(splitWords(s), "_"))
+}
+
 // FormatNumber format number with comma separator.
 //
 // code block:
@@ -89,8 +562,315 @@ func Concat(sep string, parts ...string) string {
 //
 //	100,000 Dollars
 func FormatNumber(layout string, v ...any) string {
-	p := message.NewPrinter(language.English)
-	return p.Sprintf(layout, v...)
+	return NewNumberFormatter(language.English).Sprintf(layout, v...)
+}
+
+// WordsLanguage represents language of number words.
+type WordsLanguage int
+
+const (
+	// WordsEnglish render numbers in english words.
+	WordsEnglish WordsLanguage = iota
+	// WordsPersian render numbers in persian words.
+	WordsPersian
+)
+
+// WordsCurrency represents currency units used by AmountToWords.
+// Set SubUnitDigits to zero for currencies without sub unit.
+type WordsCurrency struct {
+	Unit          string
+	UnitPlural    string
+	SubUnit       string
+	SubUnitPlural string
+	SubUnitDigits int
+}
+
+// Common currencies for AmountToWords.
+var (
+	CurrencyRial   = WordsCurrency{Unit: "ریال"}
+	CurrencyToman  = WordsCurrency{Unit: "تومان"}
+	CurrencyDollar = WordsCurrency{
+		Unit: "dollar", UnitPlural: "dollars",
+		SubUnit: "cent", SubUnitPlural: "cents",
+		SubUnitDigits: 2,
+	}
+)
+
+var (
+	englishOnes = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []string{
+		"", "thousand", "million", "billion", "trillion", "quadrillion",
+		"quintillion", "sextillion", "septillion", "octillion", "nonillion", "decillion",
+	}
+	persianOnes = []string{
+		"صفر", "یک", "دو", "سه", "چهار", "پنج", "شش", "هفت", "هشت", "نه", "ده",
+		"یازده", "دوازده", "سیزده", "چهارده", "پانزده", "شانزده", "هفده", "هجده", "نوزده",
+	}
+	persianTens     = []string{"", "", "بیست", "سی", "چهل", "پنجاه", "شصت", "هفتاد", "هشتاد", "نود"}
+	persianHundreds = []string{"", "صد", "دویست", "سیصد", "چهارصد", "پانصد", "ششصد", "هفتصد", "هشتصد", "نهصد"}
+	persianScales   = []string{
+		"", "هزار", "میلیون", "میلیارد", "تریلیون", "تریلیارد",
+		"کوادریلیون", "کوادریلیارد", "کوینتیلیون", "کوینتیلیارد", "سکستیلیون", "سکستیلیارد",
+	}
+	persianFractions = []string{
+		"", "دهم", "صدم", "هزارم", "ده هزارم", "صد هزارم",
+		"میلیونیم", "ده میلیونیم", "صد میلیونیم", "میلیاردیم",
+	}
+)
+
+// NumberToWords returns number in words.
+// n can be any integer or float type, *big.Int or localized decimal string.
+//
+// code block:
+//
+//	NumberToWords(1200005, WordsPersian)
+//	NumberToWords("-12.05", WordsEnglish)
+//
+// output:
+//
+//	یک میلیون و دویست هزار و پنج
+//	minus twelve point zero five
+func NumberToWords(n any, lang WordsLanguage) (string, error) {
+	neg, integer, fraction, err := splitDecimal(n)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := integerToWords(integer, lang)
+	if err != nil {
+		return "", err
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if fraction != "" {
+		if lang == WordsPersian {
+			if len(fraction) >= len(persianFractions) {
+				return "", errors.New("too many decimal digits")
+			}
+			words, _ := integerToWords(fraction, lang)
+			res += " ممیز " + words + " " + persianFractions[len(fraction)]
+		} else {
+			res += " point"
+			for _, d := range fraction {
+				res += " " + englishOnes[d-'0']
+			}
+		}
+	}
+
+	if neg {
+		res = wordsNegative(lang) + " " + res
+	}
+	return res, nil
+}
+
+// AmountToWords returns currency amount in words.
+// Fraction part rendered as currency sub unit.
+//
+// code block:
+//
+//	AmountToWords(12.05, WordsEnglish, CurrencyDollar)
+//	AmountToWords(150000, WordsPersian, CurrencyToman)
+//
+// output:
+//
+//	twelve dollars and five cents
+//	صد و پنجاه هزار تومان
+func AmountToWords(n any, lang WordsLanguage, currency WordsCurrency) (string, error) {
+	neg, integer, fraction, err := splitDecimal(n)
+	if err != nil {
+		return "", err
+	}
+
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > currency.SubUnitDigits {
+		return "", errors.New("too many decimal digits for currency")
+	}
+	fraction = strings.TrimLeft(fraction+strings.Repeat("0", currency.SubUnitDigits-len(fraction)), "0")
+
+	joiner := " and "
+	if lang == WordsPersian {
+		joiner = " و "
+	}
+
+	var parts []string
+	if integer != "0" || fraction == "" {
+		words, err := integerToWords(integer, lang)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, words+" "+wordsUnit(integer, currency.Unit, currency.UnitPlural))
+	}
+	if fraction != "" {
+		words, _ := integerToWords(fraction, lang)
+		parts = append(parts, words+" "+wordsUnit(fraction, currency.SubUnit, currency.SubUnitPlural))
+	}
+
+	res := strings.Join(parts, joiner)
+	if neg {
+		res = wordsNegative(lang) + " " + res
+	}
+	return res, nil
+}
+
+// splitDecimal returns sign, integer and fraction digits of number.
+func splitDecimal(n any) (bool, string, string, error) {
+	var s string
+	switch v := n.(type) {
+	case int:
+		s = strconv.FormatInt(int64(v), 10)
+	case int8:
+		s = strconv.FormatInt(int64(v), 10)
+	case int16:
+		s = strconv.FormatInt(int64(v), 10)
+	case int32:
+		s = strconv.FormatInt(int64(v), 10)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case uint:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return false, "", "", fmt.Errorf("%w: %v", ErrInvalidNumber, v)
+		}
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false, "", "", fmt.Errorf("%w: %v", ErrInvalidNumber, v)
+		}
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case *big.Int:
+		if v == nil {
+			return false, "", "", errors.New("nil number")
+		}
+		s = v.String()
+	case string:
+		normalized, err := normalizeLocalizedNumber(v)
+		if err != nil {
+			return false, "", "", err
+		}
+		s = normalized
+	default:
+		return false, "", "", fmt.Errorf("unsupported number type %T", n)
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	return neg && strings.Trim(integer+fraction, "0") != "", integer, fraction, nil
+}
+
+// integerToWords returns words of non-negative integer digits.
+func integerToWords(digits string, lang WordsLanguage) (string, error) {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		if lang == WordsPersian {
+			return persianOnes[0], nil
+		}
+		return englishOnes[0], nil
+	}
+
+	scales, joiner := englishScales, " "
+	if lang == WordsPersian {
+		scales, joiner = persianScales, " و "
+	}
+
+	// Split to 3 digit groups from right
+	var groups []int
+	for len(digits) > 0 {
+		start := Max(len(digits)-3, 0)
+		g, _ := strconv.Atoi(digits[start:])
+		groups = append(groups, g)
+		digits = digits[:start]
+	}
+	if len(groups) > len(scales) {
+		return "", errors.New("number is too large")
+	}
+
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+
+		var words string
+		if lang == WordsPersian {
+			words = persianGroupWords(groups[i])
+		} else {
+			words = englishGroupWords(groups[i])
+		}
+		if scales[i] != "" {
+			words += " " + scales[i]
+		}
+		parts = append(parts, words)
+	}
+	return strings.Join(parts, joiner), nil
+}
+
+// englishGroupWords returns english words of number less than 1000.
+func englishGroupWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, englishOnes[n/100]+" hundred")
+		n %= 100
+	}
+	if n >= 20 {
+		words := englishTens[n/10]
+		if n%10 != 0 {
+			words += "-" + englishOnes[n%10]
+		}
+		parts = append(parts, words)
+	} else if n > 0 {
+		parts = append(parts, englishOnes[n])
+	}
+	return strings.Join(parts, " ")
+}
+
+// persianGroupWords returns persian words of number less than 1000.
+func persianGroupWords(n int) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, persianHundreds[n/100])
+		n %= 100
+	}
+	if n >= 20 {
+		parts = append(parts, persianTens[n/10])
+		n %= 10
+	}
+	if n > 0 {
+		parts = append(parts, persianOnes[n])
+	}
+	return strings.Join(parts, " و ")
+}
+
+// wordsUnit returns singular or plural unit name.
+func wordsUnit(digits, unit, plural string) string {
+	if digits == "1" || plural == "" {
+		return unit
+	}
+	return plural
+}
+
+// wordsNegative returns negative sign word.
+func wordsNegative(lang WordsLanguage) string {
+	if lang == WordsPersian {
+		return "منفی"
+	}
+	return "minus"
 }
 
 // FormatRx format string using regex pattern
@@ -104,9 +884,144 @@ func FormatNumber(layout string, v ...any) string {
 //
 //	(123) 45-6
 func FormatRx(data, pattern, repl string) (string, error) {
-	rx, err := regexp.Compile("^" + pattern + "$")
+	rx, err := compileRegex("^" + pattern + "$")
 	if err != nil {
 		return "", err
 	}
 	return rx.ReplaceAllString(data, repl), nil
 }
+
+// isDigit check if rune is ascii digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isAlphaNum check if rune is ascii letter or digit.
+func isAlphaNum(r rune) bool {
+	return isDigit(r) || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// isPersianAlphaNum check if rune is ascii letter, digit or persian character.
+func isPersianAlphaNum(r rune) bool {
+	return isAlphaNum(r) || (r >= '\u0600' && r <= '\u06FF') || r == '\uFB8A'
+}
+
+// filterRunes returns string with runes that keep returns true.
+func filterRunes(s string, keep func(r rune) bool) string {
+	return strings.Map(func(r rune) rune {
+		if keep(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// slugify keep runes that keep returns true and replace
+// whitespace and dash sequences with single dash.
+func slugify(s string, keep func(r rune) bool) string {
+	var res strings.Builder
+	dash := false
+	for _, r := range s {
+		switch {
+		case keep(r):
+			if dash {
+				res.WriteByte('-')
+				dash = false
+			}
+			res.WriteRune(r)
+		case r == '-' || r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f':
+			dash = true
+		}
+	}
+	if dash {
+		res.WriteByte('-')
+	}
+	return res.String()
+}
+
+// splitWords split string to words on separators, case changes
+// and acronym boundaries (e.g. "HTTPServerID" to HTTP, Server, ID).
+// Digits are kept with previous word and plural acronyms kept together (e.g. "UserIDs" to User, IDs).
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralSuffix(runes, i+1)
+			if unicode.IsLower(prev) || unicode.IsNumber(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// isPluralSuffix check if rune at i is lowercase s that ends the word.
+func isPluralSuffix(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// graphemes split string to approximate extended grapheme clusters.
+// Combining marks, variation selectors, emoji modifiers, tags,
+// zero width joiner sequences and regional indicator pairs are kept together.
+func graphemes(s string) []string {
+	runes := []rune(s)
+	res := make([]string, 0, len(runes))
+	for i := 0; i < len(runes); {
+		start := i
+		regional := isRegionalIndicator(runes[i])
+		i++
+
+		for i < len(runes) {
+			r := runes[i]
+			if r == '\u200d' && i+1 < len(runes) {
+				i += 2
+			} else if isGraphemeExtend(r) || (r == '\n' && runes[i-1] == '\r') {
+				i++
+			} else if regional && isRegionalIndicator(r) {
+				regional = false
+				i++
+			} else {
+				break
+			}
+		}
+		res = append(res, string(runes[start:i]))
+	}
+	return res
+}
+
+// isGraphemeExtend check if rune extends previous grapheme cluster
+// (combining marks, variation selectors, emoji modifiers and tags).
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= '\uFE00' && r <= '\uFE0F') ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) ||
+		(r >= 0xE0020 && r <= 0xE007F)
+}
+
+// isRegionalIndicator check if rune is flag regional indicator symbol.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isSpaceCluster check if grapheme cluster is whitespace.
+func isSpaceCluster(cluster string) bool {
+	return strings.TrimSpace(cluster) == ""
+}
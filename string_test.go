@@ -1,8 +1,15 @@
 package goutils_test
 
 import (
+	"bytes"
+	"math"
+	"math/big"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/mekramy/goutils"
 )
@@ -16,6 +23,15 @@ func TestExtractNumbers(t *testing.T) {
 	}
 }
 
+func TestExtractLocalizedNumbers(t *testing.T) {
+	input := "تلفن: ۰۹۱۲-٣٤٥ 67"
+	expected := "091234567"
+	result := goutils.ExtractLocalizedNumbers(input)
+	if result != expected {
+		t.Errorf("ExtractLocalizedNumbers(%q) = %q; want %q", input, result, expected)
+	}
+}
+
 func TestExtractAlphaNum(t *testing.T) {
 	input := "abc123!@_def456"
 	expected := "abc123def456"
@@ -34,6 +50,67 @@ func TestExtractAlphaNumPersian(t *testing.T) {
 	}
 }
 
+func TestExtractAlphaNumIncludes(t *testing.T) {
+	tests := []struct {
+		input    string
+		includes string
+		expected string
+	}{
+		{"a-b_c d!", "-_", "a-b_cd"},
+		{"a]b^c\\d[e", "]^\\", "a]b^c\\de"},
+		{`a\sb c`, `\s`, `a\sbc`},
+	}
+
+	for _, test := range tests {
+		result := goutils.ExtractAlphaNum(test.input, test.includes)
+		if result != test.expected {
+			t.Errorf("ExtractAlphaNum(%q, %q) = %q; want %q", test.input, test.includes, result, test.expected)
+		}
+	}
+
+	input := "سلام]-[دنیا"
+	expected := "سلام]-دنیا"
+	if result := goutils.ExtractAlphaNumPersian(input, "]-"); result != expected {
+		t.Errorf("ExtractAlphaNumPersian(%q) = %q; want %q", input, result, expected)
+	}
+}
+
+func TestExtractAlphaNumRx(t *testing.T) {
+	input := "a-b c!"
+	expected := "a-b c"
+	result, err := goutils.ExtractAlphaNumRx(input, `\s\-`)
+	if err != nil || result != expected {
+		t.Errorf("ExtractAlphaNumRx(%q) = %q, %v; want %q", input, result, err, expected)
+	}
+
+	if _, err := goutils.ExtractAlphaNumRx(input, `\`); err == nil {
+		t.Errorf("ExtractAlphaNumRx with invalid pattern should return error")
+	}
+	if _, err := goutils.ExtractAlphaNumPersianRx(input, `a-\`); err == nil {
+		t.Errorf("ExtractAlphaNumPersianRx with invalid pattern should return error")
+	}
+}
+
+func TestExtractRunes(t *testing.T) {
+	tests := []struct {
+		input    string
+		allowed  string
+		tables   []*unicode.RangeTable
+		expected string
+	}{
+		{"Hello, [World]! 123", "[]", []*unicode.RangeTable{unicode.Latin}, "Hello[World]"},
+		{"abc 123 سلام!", " ", []*unicode.RangeTable{goutils.AlphaNumTable}, "abc 123 "},
+		{"abc 123 سلام!", "", []*unicode.RangeTable{goutils.PersianTable, unicode.Punct}, "سلام!"},
+	}
+
+	for _, test := range tests {
+		result := goutils.ExtractRunes(test.input, test.allowed, test.tables...)
+		if result != test.expected {
+			t.Errorf("ExtractRunes(%q, %q) = %q; want %q", test.input, test.allowed, result, test.expected)
+		}
+	}
+}
+
 func TestRandomNumeric(t *testing.T) {
 	length := uint(10)
 	result := goutils.RandomNumeric(length)
@@ -56,6 +133,79 @@ func TestRandomAlphaNum(t *testing.T) {
 	}
 }
 
+func TestRandomStringFrom(t *testing.T) {
+	// Each index reads 8 bytes big-endian: 0, 1, 2 => "abc"
+	src := bytes.NewReader([]byte{
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 2,
+	})
+	result, err := goutils.RandomStringFrom(src, 3, "abc")
+	if err != nil {
+		t.Fatalf("RandomStringFrom returned error: %v", err)
+	}
+	if result != "abc" {
+		t.Errorf("RandomStringFrom() = %q; want %q", result, "abc")
+	}
+
+	// Values in biased tail must be rejected
+	src = bytes.NewReader([]byte{
+		255, 255, 255, 255, 255, 255, 255, 255,
+		0, 0, 0, 0, 0, 0, 0, 1,
+	})
+	result, err = goutils.RandomStringFrom(src, 1, "abc")
+	if err != nil {
+		t.Fatalf("RandomStringFrom returned error: %v", err)
+	}
+	if result != "b" {
+		t.Errorf("RandomStringFrom() = %q; want %q", result, "b")
+	}
+
+	if _, err := goutils.RandomStringFrom(bytes.NewReader(nil), 1, "abc"); err == nil {
+		t.Errorf("RandomStringFrom with exhausted source should return error")
+	}
+	if _, err := goutils.RandomStringFrom(bytes.NewReader(nil), 1, ""); err == nil {
+		t.Errorf("RandomStringFrom with empty characters should return error")
+	}
+}
+
+func TestRandomStringUnicode(t *testing.T) {
+	result := goutils.RandomString(20, goutils.CharsPersian+"😀")
+	if !utf8.ValidString(result) {
+		t.Errorf("RandomString() = %q; want valid utf-8 string", result)
+	}
+	if n := utf8.RuneCountInString(result); n != 20 {
+		t.Errorf("RandomString() rune count = %d; want %d", n, 20)
+	}
+}
+
+func TestExcludeLookAlike(t *testing.T) {
+	input := goutils.CharsUpper + goutils.CharsDigits
+	result := goutils.ExcludeLookAlike(input)
+	if strings.ContainsAny(result, "0O1lI") {
+		t.Errorf("ExcludeLookAlike(%q) = %q; contains look-alike characters", input, result)
+	}
+}
+
+func TestRandomPolicyString(t *testing.T) {
+	classes := []string{goutils.CharsLower, goutils.CharsUpper, goutils.CharsDigits, goutils.CharsSymbols}
+	for i := 0; i < 50; i++ {
+		result, err := goutils.RandomPolicyString(4, classes...)
+		if err != nil {
+			t.Fatalf("RandomPolicyString returned error: %v", err)
+		}
+		for _, class := range classes {
+			if !strings.ContainsAny(result, class) {
+				t.Fatalf("RandomPolicyString() = %q; want at least one of %q", result, class)
+			}
+		}
+	}
+
+	if _, err := goutils.RandomPolicyString(2, classes...); err == nil {
+		t.Errorf("RandomPolicyString with short length should return error")
+	}
+}
+
 func TestSlugify(t *testing.T) {
 	input := []string{"Hello-- ", "  World!"}
 	expected := "Hello-World"
@@ -74,6 +224,59 @@ func TestSlugifyPersian(t *testing.T) {
 	}
 }
 
+func TestSlugifyWith(t *testing.T) {
+	tests := []struct {
+		options  goutils.SlugOptions
+		input    []string
+		expected string
+	}{
+		{goutils.SlugOptions{Lowercase: true, Transliterate: true}, []string{"Café Münch"}, "cafe-munch"},
+		{goutils.SlugOptions{Lowercase: true, Transliterate: true}, []string{"کافه", "تهران"}, "kafe-tehran"},
+		{goutils.SlugOptions{}, []string{"--Hello", "World!--"}, "Hello-World"},
+		{goutils.SlugOptions{Separator: "_", Lowercase: true}, []string{"سلام دنیا", "Go"}, "سلام_دنیا_go"},
+		{goutils.SlugOptions{Lowercase: true, StopWords: []string{"the", "of"}}, []string{"The Lord of the Rings"}, "lord-rings"},
+		{goutils.SlugOptions{Lowercase: true, MaxLength: 12}, []string{"hello beautiful world"}, "hello"},
+		{goutils.SlugOptions{MaxLength: 4}, []string{"extraordinary"}, "extr"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.SlugifyWith(test.options, test.input...)
+		if err != nil || result != test.expected {
+			t.Errorf("SlugifyWith(%+v, %q) = %q, %v; want %q", test.options, test.input, result, err, test.expected)
+		}
+	}
+}
+
+func TestSlugifyWithExists(t *testing.T) {
+	existing := map[string]bool{"hello-world": true, "hello-world-2": true, "hello-2": true, "hello-3": true}
+	options := goutils.SlugOptions{
+		Lowercase: true,
+		Exists: func(slug string) (bool, error) {
+			return existing[slug], nil
+		},
+	}
+
+	result, err := goutils.SlugifyWith(options, "Hello World")
+	if err != nil || result != "hello-world-3" {
+		t.Errorf("SlugifyWith() = %q, %v; want %q", result, err, "hello-world-3")
+	}
+
+	options.MaxLength = 11
+	result, err = goutils.SlugifyWith(options, "Hello World")
+	if err != nil || result != "hello-4" {
+		t.Errorf("SlugifyWith() = %q, %v; want %q", result, err, "hello-4")
+	}
+
+	calls := 0
+	options.Exists = func(string) (bool, error) {
+		calls++
+		return true, nil
+	}
+	if _, err := goutils.SlugifyWith(options, "Hello World"); err == nil || calls != 1000 {
+		t.Errorf("SlugifyWith() with taken slugs = %v after %d calls; want error after 1000 calls", err, calls)
+	}
+}
+
 func TestConcat(t *testing.T) {
 	input := []string{"Hello", "", "      ", "World"}
 	sep := " "
@@ -84,6 +287,87 @@ func TestConcat(t *testing.T) {
 	}
 }
 
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		input          string
+		camel          string
+		pascal         string
+		snake          string
+		kebab          string
+		screamingSnake string
+		title          string
+	}{
+		{"HTTPServerID", "httpServerID", "HTTPServerID", "http_server_id", "http-server-id", "HTTP_SERVER_ID", "HTTP Server ID"},
+		{"user_id", "userID", "UserID", "user_id", "user-id", "USER_ID", "User ID"},
+		{"  hello-world  foo", "helloWorldFoo", "HelloWorldFoo", "hello_world_foo", "hello-world-foo", "HELLO_WORLD_FOO", "Hello World Foo"},
+		{"UTF8Reader", "utf8Reader", "UTF8Reader", "utf8_reader", "utf8-reader", "UTF8_READER", "UTF8 Reader"},
+		{"jsonApiURL", "jsonAPIURL", "JSONAPIURL", "json_api_url", "json-api-url", "JSON_API_URL", "JSON API URL"},
+		{"UserIDs", "userIDs", "UserIDs", "user_ids", "user-ids", "USER_IDS", "User IDs"},
+		{"APIs", "apis", "APIs", "apis", "apis", "APIS", "APIs"},
+		{"listURLsByIPs", "listURLsByIPs", "ListURLsByIPs", "list_urls_by_ips", "list-urls-by-ips", "LIST_URLS_BY_IPS", "List URLs By IPs"},
+		{"HTTPSServer", "httpsServer", "HTTPSServer", "https_server", "https-server", "HTTPS_SERVER", "HTTPS Server"},
+		{"ÉcoleNationale", "écoleNationale", "ÉcoleNationale", "école_nationale", "école-nationale", "ÉCOLE_NATIONALE", "École Nationale"},
+		{"نام_کاربری", "نامکاربری", "نامکاربری", "نام_کاربری", "نام-کاربری", "نام_کاربری", "نام کاربری"},
+		{"", "", "", "", "", "", ""},
+	}
+
+	for _, test := range tests {
+		results := []struct{ name, result, expected string }{
+			{"ToCamel", goutils.ToCamel(test.input), test.camel},
+			{"ToPascal", goutils.ToPascal(test.input), test.pascal},
+			{"ToSnake", goutils.ToSnake(test.input), test.snake},
+			{"ToKebab", goutils.ToKebab(test.input), test.kebab},
+			{"ToScreamingSnake", goutils.ToScreamingSnake(test.input), test.screamingSnake},
+			{"ToTitle", goutils.ToTitle(test.input), test.title},
+		}
+		for _, r := range results {
+			if r.result != r.expected {
+				t.Errorf("%s(%q) = %q; want %q", r.name, test.input, r.result, r.expected)
+			}
+		}
+	}
+}
+
+func TestCaseConverter(t *testing.T) {
+	c := goutils.NewCaseConverter("db", "sku")
+	if result := c.Pascal("product_sku_db_id"); result != "ProductSKUDBId" {
+		t.Errorf("Pascal() = %q; want %q", result, "ProductSKUDBId")
+	}
+	if result := c.Camel("SKUCode"); result != "skuCode" {
+		t.Errorf("Camel() = %q; want %q", result, "skuCode")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	family := "👨‍👩‍👧‍👦"
+	tests := []struct {
+		input    string
+		n        int
+		options  goutils.TruncateOptions
+		expected string
+	}{
+		{"Hello", 10, goutils.TruncateOptions{Ellipsis: "…"}, "Hello"},
+		{"Hello World", 8, goutils.TruncateOptions{}, "Hello Wo"},
+		{"Hello World", 8, goutils.TruncateOptions{Ellipsis: "…"}, "Hello W…"},
+		{"Hello beautiful world", 12, goutils.TruncateOptions{WordBoundary: true, Ellipsis: "…"}, "Hello…"},
+		{"Extraordinary", 6, goutils.TruncateOptions{WordBoundary: true, Ellipsis: "..."}, "Ext..."},
+		{"سلام دنیای زیبا", 10, goutils.TruncateOptions{WordBoundary: true}, "سلام دنیای"},
+		{"ab" + family + "cd", 4, goutils.TruncateOptions{Graphemes: true}, "ab" + family + "c"},
+		{"ab" + family + "cd", 4, goutils.TruncateOptions{}, "ab"},
+		{"🇮🇷🇩🇪🇫🇷", 2, goutils.TruncateOptions{Graphemes: true}, "🇮🇷🇩🇪"},
+		{"e\u0301e\u0301e\u0301", 2, goutils.TruncateOptions{Graphemes: true}, "e\u0301e\u0301"},
+		{"👍🏽👍🏽", 3, goutils.TruncateOptions{}, "👍🏽"},
+		{"Hello", 2, goutils.TruncateOptions{Ellipsis: "..."}, "He"},
+	}
+
+	for _, test := range tests {
+		result := goutils.Truncate(test.input, test.n, test.options)
+		if result != test.expected {
+			t.Errorf("Truncate(%q, %d, %+v) = %q; want %q", test.input, test.n, test.options, result, test.expected)
+		}
+	}
+}
+
 func TestFormatNumber(t *testing.T) {
 	layout := "%d Dollars"
 	value := 100000
@@ -94,6 +378,69 @@ func TestFormatNumber(t *testing.T) {
 	}
 }
 
+func TestNumberToWords(t *testing.T) {
+	huge, _ := new(big.Int).SetString("1000000000000000000001", 10)
+	tests := []struct {
+		input    any
+		lang     goutils.WordsLanguage
+		expected string
+	}{
+		{0, goutils.WordsEnglish, "zero"},
+		{int64(-1234), goutils.WordsEnglish, "minus one thousand two hundred thirty-four"},
+		{uint(1000010), goutils.WordsEnglish, "one million ten"},
+		{"-12.05", goutils.WordsEnglish, "minus twelve point zero five"},
+		{huge, goutils.WordsEnglish, "one sextillion one"},
+		{0, goutils.WordsPersian, "صفر"},
+		{1000, goutils.WordsPersian, "یک هزار"},
+		{123, goutils.WordsPersian, "صد و بیست و سه"},
+		{1200005, goutils.WordsPersian, "یک میلیون و دویست هزار و پنج"},
+		{-45, goutils.WordsPersian, "منفی چهل و پنج"},
+		{12.5, goutils.WordsPersian, "دوازده ممیز پنج دهم"},
+		{"۳٫۱۴", goutils.WordsPersian, "سه ممیز چهارده صدم"},
+		{float32(0.25), goutils.WordsEnglish, "zero point two five"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.NumberToWords(test.input, test.lang)
+		if err != nil || result != test.expected {
+			t.Errorf("NumberToWords(%v, %d) = %q, %v; want %q", test.input, test.lang, result, err, test.expected)
+		}
+	}
+
+	for _, invalid := range []any{"12a", math.NaN(), struct{}{}, "0.1234567891"} {
+		if _, err := goutils.NumberToWords(invalid, goutils.WordsPersian); err == nil {
+			t.Errorf("NumberToWords(%v) should return error", invalid)
+		}
+	}
+}
+
+func TestAmountToWords(t *testing.T) {
+	tests := []struct {
+		input    any
+		lang     goutils.WordsLanguage
+		currency goutils.WordsCurrency
+		expected string
+	}{
+		{12.05, goutils.WordsEnglish, goutils.CurrencyDollar, "twelve dollars and five cents"},
+		{1, goutils.WordsEnglish, goutils.CurrencyDollar, "one dollar"},
+		{"0.01", goutils.WordsEnglish, goutils.CurrencyDollar, "one cent"},
+		{0, goutils.WordsEnglish, goutils.CurrencyDollar, "zero dollars"},
+		{150000, goutils.WordsPersian, goutils.CurrencyToman, "صد و پنجاه هزار تومان"},
+		{"۲۵۰۰", goutils.WordsPersian, goutils.CurrencyRial, "دو هزار و پانصد ریال"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.AmountToWords(test.input, test.lang, test.currency)
+		if err != nil || result != test.expected {
+			t.Errorf("AmountToWords(%v, %d) = %q, %v; want %q", test.input, test.lang, result, err, test.expected)
+		}
+	}
+
+	if _, err := goutils.AmountToWords(12.5, goutils.WordsPersian, goutils.CurrencyRial); err == nil {
+		t.Errorf("AmountToWords with fraction for rial should return error")
+	}
+}
+
 func TestFormatRx(t *testing.T) {
 	data := "123456"
 	pattern := `(\d{3})(\d{2})(\d{1})`
@@ -107,3 +454,70 @@ func TestFormatRx(t *testing.T) {
 		t.Errorf("FormatRx(%q, %q, %q) = %q; want %q", data, pattern, repl, result, expected)
 	}
 }
+
+func TestFormatRxConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pattern := `(\d{3})(\d{2})(\d{1})` + strings.Repeat(`\d{0}`, i%5)
+			result, err := goutils.FormatRx("123456", pattern, "($1) $2-$3")
+			if err != nil || result != "(123) 45-6" {
+				t.Errorf("FormatRx(%q) = %q, %v", pattern, result, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+var benchmarkInput = strings.Repeat("Hello, World! 123-456 سلام دنیا ", 8)
+
+func BenchmarkExtractNumbers(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		goutils.ExtractNumbers(benchmarkInput)
+	}
+}
+
+func BenchmarkExtractNumbersRegex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		regexp.MustCompile(`[^0-9]`).ReplaceAllString(benchmarkInput, "")
+	}
+}
+
+func BenchmarkExtractAlphaNum(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		goutils.ExtractAlphaNum(benchmarkInput)
+	}
+}
+
+func BenchmarkExtractAlphaNumRegex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(benchmarkInput, "")
+	}
+}
+
+func BenchmarkSlugify(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		goutils.Slugify(benchmarkInput)
+	}
+}
+
+func BenchmarkSlugifyRegex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		normalized := regexp.MustCompile(`[^a-zA-Z0-9\s\-]`).ReplaceAllString(benchmarkInput, "")
+		regexp.MustCompile(`[\s\-]+`).ReplaceAllString(normalized, "-")
+	}
+}
+
+func BenchmarkFormatRx(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		goutils.FormatRx("09121234567", `(\d{4})(\d{3})(\d{4})`, "($1) $2-$3")
+	}
+}
+
+func BenchmarkFormatRxRegex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		regexp.MustCompile(`^(\d{4})(\d{3})(\d{4})$`).ReplaceAllString("09121234567", "($1) $2-$3")
+	}
+}
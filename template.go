@@ -0,0 +1,255 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingKey returned by strict template execution when data key not exists.
+var ErrMissingKey = errors.New("missing template key")
+
+// templateCache contains parsed templates.
+var templateCache = newLRUCache[string, *Template](256)
+
+// templatePipes contains available template pipes.
+var templatePipes = map[string]func(v any, arg string) (any, error){
+	"number": func(v any, _ string) (any, error) {
+		if s, ok := v.(string); ok {
+			n, err := ParseLocalizedFloat(s)
+			if err != nil {
+				return nil, err
+			}
+			v = n
+		}
+		return FormatNumber("%v", v), nil
+	},
+	"slug": func(v any, _ string) (any, error) {
+		return Slugify(fmt.Sprint(v)), nil
+	},
+	"upper": func(v any, _ string) (any, error) {
+		return strings.ToUpper(fmt.Sprint(v)), nil
+	},
+	"lower": func(v any, _ string) (any, error) {
+		return strings.ToLower(fmt.Sprint(v)), nil
+	},
+	"trim": func(v any, _ string) (any, error) {
+		return strings.TrimSpace(fmt.Sprint(v)), nil
+	},
+	"title": func(v any, _ string) (any, error) {
+		return ToTitle(fmt.Sprint(v)), nil
+	},
+	"persian": func(v any, _ string) (any, error) {
+		return ToPersianDigits(fmt.Sprint(v)), nil
+	},
+	"default": func(v any, arg string) (any, error) {
+		if isEmptyTemplateValue(v) {
+			return arg, nil
+		}
+		return v, nil
+	},
+}
+
+// Template represents parsed template with named placeholders.
+// Template is safe for concurrent use.
+type Template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	text  string
+	key   string
+	pipes []templatePipe
+}
+
+type templatePipe struct {
+	name string
+	arg  string
+}
+
+// ParseTemplate parse template with {key|pipe|pipe:"arg"} placeholders.
+// Use {{ and }} for literal braces. Parsed templates are cached.
+//
+// Available pipes: number, slug, upper, lower, trim, title, persian (digits) and default:"value".
+func ParseTemplate(tpl string) (*Template, error) {
+	if t, ok := templateCache.Get(tpl); ok {
+		return t, nil
+	}
+
+	t := new(Template)
+	var text strings.Builder
+	runes := []rune(tpl)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case (r == '{' || r == '}') && i+1 < len(runes) && runes[i+1] == r:
+			text.WriteRune(r)
+			i++
+		case r == '}':
+			return nil, fmt.Errorf("unexpected } at %d", i)
+		case r == '{':
+			end := placeholderEnd(runes, i+1)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at %d", i)
+			}
+
+			part, err := parsePlaceholder(string(runes[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			part.text = string(runes[i : end+1])
+			if text.Len() > 0 {
+				t.parts = append(t.parts, templatePart{text: text.String()})
+				text.Reset()
+			}
+			t.parts = append(t.parts, part)
+			i = end
+		default:
+			text.WriteRune(r)
+		}
+	}
+	if text.Len() > 0 {
+		t.parts = append(t.parts, templatePart{text: text.String()})
+	}
+
+	templateCache.Set(tpl, t)
+	return t, nil
+}
+
+// Execute render template with data.
+// Missing keys returns ErrMissingKey in strict mode, otherwise placeholder kept as is.
+// Placeholders with default pipe never considered missing,
+// pipes before default skipped for missing, nil and empty values.
+func (t *Template) Execute(data map[string]any, strict bool) (string, error) {
+	var res strings.Builder
+	for _, part := range t.parts {
+		if part.key == "" {
+			res.WriteString(part.text)
+			continue
+		}
+
+		value, ok := data[part.key]
+		if !ok && !part.hasDefault() {
+			if strict {
+				return "", fmt.Errorf("%w: %s", ErrMissingKey, part.key)
+			}
+			res.WriteString(part.text)
+			continue
+		}
+
+		pipes := part.pipes
+		if isEmptyTemplateValue(value) {
+			if defaults := part.defaultPipes(); defaults != nil || value == nil {
+				pipes = defaults
+			}
+		}
+		for _, pipe := range pipes {
+			var err error
+			if value, err = templatePipes[pipe.name](value, pipe.arg); err != nil {
+				return "", fmt.Errorf("pipe %s on %s: %w", pipe.name, part.key, err)
+			}
+		}
+		if value != nil {
+			res.WriteString(fmt.Sprint(value))
+		}
+	}
+	return res.String(), nil
+}
+
+// Interpolate render template with named placeholders and keep missing keys as is.
+//
+// code block:
+//
+//	Interpolate("Hello {name|default:\"guest\"}, you owe {amount|number}", map[string]any{"amount": 1250000})
+//
+// output:
+//
+//	Hello guest, you owe 1,250,000
+func Interpolate(tpl string, data map[string]any) (string, error) {
+	t, err := ParseTemplate(tpl)
+	if err != nil {
+		return "", err
+	}
+	return t.Execute(data, false)
+}
+
+// InterpolateStrict render template with named placeholders and returns ErrMissingKey for missing keys.
+func InterpolateStrict(tpl string, data map[string]any) (string, error) {
+	t, err := ParseTemplate(tpl)
+	if err != nil {
+		return "", err
+	}
+	return t.Execute(data, true)
+}
+
+// hasDefault check if placeholder has default pipe.
+func (p templatePart) hasDefault() bool {
+	return len(p.defaultPipes()) > 0
+}
+
+// defaultPipes returns pipes starting from first default pipe.
+func (p templatePart) defaultPipes() []templatePipe {
+	for i, pipe := range p.pipes {
+		if pipe.name == "default" {
+			return p.pipes[i:]
+		}
+	}
+	return nil
+}
+
+// isEmptyTemplateValue check if value is nil or renders to empty string.
+func isEmptyTemplateValue(v any) bool {
+	return v == nil || fmt.Sprint(v) == ""
+}
+
+// placeholderEnd returns index of placeholder closing brace outside quotes or -1.
+func placeholderEnd(runes []rune, start int) int {
+	quoted := false
+	for i := start; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			quoted = !quoted
+		case '}':
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parsePlaceholder parse key|pipe|pipe:"arg" placeholder content.
+func parsePlaceholder(content string) (templatePart, error) {
+	var segments []string
+	quoted, start := false, 0
+	for i, r := range content {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == '|' && !quoted:
+			segments = append(segments, content[start:i])
+			start = i + 1
+		}
+	}
+	segments = append(segments, content[start:])
+
+	part := templatePart{key: strings.TrimSpace(segments[0])}
+	if part.key == "" {
+		return part, fmt.Errorf("empty placeholder key in {%s}", content)
+	}
+
+	for _, segment := range segments[1:] {
+		name, arg, _ := strings.Cut(strings.TrimSpace(segment), ":")
+		name = strings.TrimSpace(name)
+		if _, ok := templatePipes[name]; !ok {
+			return part, fmt.Errorf("unknown pipe %q in {%s}", name, content)
+		}
+
+		arg = strings.TrimSpace(arg)
+		if len(arg) >= 2 && strings.HasPrefix(arg, `"`) && strings.HasSuffix(arg, `"`) {
+			arg = arg[1 : len(arg)-1]
+		}
+		part.pipes = append(part.pipes, templatePipe{name: name, arg: arg})
+	}
+	return part, nil
+}
@@ -0,0 +1,75 @@
+package goutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestInterpolate(t *testing.T) {
+	data := map[string]any{
+		"name":   "John",
+		"amount": 1250000,
+		"title":  "Hello World!",
+		"empty":  "",
+		"price":  "۱۲۳۴۵",
+		"nil":    nil,
+	}
+	tests := []struct {
+		tpl      string
+		expected string
+	}{
+		{"Hello {name}, you owe {amount|number}", "Hello John, you owe 1,250,000"},
+		{"{title|slug|lower}", "hello-world"},
+		{"{name|upper} {missing}", "JOHN {missing}"},
+		{`{missing|default:"guest"} {empty|default:"none"}`, "guest none"},
+		{`{missing|default:"a|b}c"|upper}`, "A|B}C"},
+		{`{missing|upper|default:"x"}`, "x"},
+		{`{empty|number|default:"0"} [{empty|upper}]`, "0 []"},
+		{`{nil|number|default:"0"} [{nil|upper}]`, "0 []"},
+		{"{price|number} {amount|persian}", "12,345 ۱۲۵۰۰۰۰"},
+		{"{{name}} {{{name}}}", "{name} {John}"},
+		{"no placeholders", "no placeholders"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.Interpolate(test.tpl, data)
+		if err != nil || result != test.expected {
+			t.Errorf("Interpolate(%q) = %q, %v; want %q", test.tpl, result, err, test.expected)
+		}
+	}
+}
+
+func TestInterpolateStrict(t *testing.T) {
+	data := map[string]any{"name": "John"}
+	if _, err := goutils.InterpolateStrict("Hello {name} {missing}", data); !errors.Is(err, goutils.ErrMissingKey) {
+		t.Errorf("InterpolateStrict() error = %v; want ErrMissingKey", err)
+	}
+
+	result, err := goutils.InterpolateStrict(`Hello {name} {missing|default:"x"}`, data)
+	if err != nil || result != "Hello John x" {
+		t.Errorf("InterpolateStrict() = %q, %v; want %q", result, err, "Hello John x")
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	for _, invalid := range []string{"{name", "name}", "{}", "{name|unknown}", `{name|default:"x}`} {
+		if _, err := goutils.ParseTemplate(invalid); err == nil {
+			t.Errorf("ParseTemplate(%q) should return error", invalid)
+		}
+	}
+
+	a, _ := goutils.ParseTemplate("Hello {name}")
+	b, _ := goutils.ParseTemplate("Hello {name}")
+	if a != b {
+		t.Errorf("ParseTemplate should return cached template")
+	}
+
+	if _, err := a.Execute(map[string]any{"name": "x"}, true); err != nil {
+		t.Errorf("Execute() returned error: %v", err)
+	}
+	if _, err := goutils.Interpolate("{name|number}", map[string]any{"name": "abc"}); err == nil {
+		t.Errorf("Interpolate with invalid number should return error")
+	}
+}
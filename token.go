@@ -0,0 +1,335 @@
+package goutils
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	sortableTimeSize  = 9
+	sortableMaxMillis = 253402300799999 // 9999-12-31T23:59:59.999Z
+)
+
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+// TokenEncoding represents text encoding of random token.
+type TokenEncoding int
+
+const (
+	// TokenHex encode token as lowercase hex.
+	TokenHex TokenEncoding = iota
+	// TokenBase32 encode token as crockford base32.
+	TokenBase32
+	// TokenBase58 encode token as bitcoin base58.
+	TokenBase58
+	// TokenBase62 encode token as alpha-num base62.
+	TokenBase62
+	// TokenBase64 encode token as url-safe base64 without padding.
+	TokenBase64
+)
+
+// GenerateToken returns n cryptographically secure random bytes encoded with encoding.
+func GenerateToken(n uint, encoding TokenEncoding) (string, error) {
+	return GenerateTokenFrom(rand.Reader, n, encoding)
+}
+
+// GenerateTokenFrom returns n random bytes encoded with encoding using src as random source.
+func GenerateTokenFrom(src RandomSource, n uint, encoding TokenEncoding) (string, error) {
+	data := make([]byte, n)
+	if _, err := io.ReadFull(src, data); err != nil {
+		return "", err
+	}
+	return EncodeToken(data, encoding)
+}
+
+// EncodeToken encode data with token encoding.
+func EncodeToken(data []byte, encoding TokenEncoding) (string, error) {
+	switch encoding {
+	case TokenHex:
+		return hex.EncodeToString(data), nil
+	case TokenBase32:
+		return crockfordEncoding.EncodeToString(data), nil
+	case TokenBase58:
+		return encodeBase(data, base58Alphabet), nil
+	case TokenBase62:
+		return encodeBase(data, base62Alphabet), nil
+	case TokenBase64:
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	default:
+		return "", errors.New("unknown token encoding")
+	}
+}
+
+// DecodeToken decode token encoded with token encoding to raw bytes.
+func DecodeToken(token string, encoding TokenEncoding) ([]byte, error) {
+	switch encoding {
+	case TokenHex:
+		return hex.DecodeString(token)
+	case TokenBase32:
+		return crockfordEncoding.DecodeString(normalizeCrockford(token))
+	case TokenBase58:
+		return decodeBase(token, base58Alphabet)
+	case TokenBase62:
+		return decodeBase(token, base62Alphabet)
+	case TokenBase64:
+		return base64.RawURLEncoding.DecodeString(token)
+	default:
+		return nil, errors.New("unknown token encoding")
+	}
+}
+
+// UUID represents RFC 9562 universally unique identifier.
+type UUID [16]byte
+
+// NewUUIDv4 generate random UUID version 4.
+func NewUUIDv4() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(rand.Reader, u[:]); err != nil {
+		return u, err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return u, nil
+}
+
+// NewUUIDv7 generate time-ordered UUID version 7.
+func NewUUIDv7() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(rand.Reader, u[6:]); err != nil {
+		return u, err
+	}
+	putMillis(u[:6], time.Now())
+	u[6] = (u[6] & 0x0f) | 0x70
+	u[8] = (u[8] & 0x3f) | 0x80
+	return u, nil
+}
+
+// ParseUUID parse UUID from canonical (8-4-4-4-12) or 32 hex characters form.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, errors.New("invalid uuid format")
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return u, errors.New("invalid uuid length")
+	}
+
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, errors.New("invalid uuid format")
+	}
+	return u, nil
+}
+
+// String returns canonical lowercase form of UUID.
+func (u UUID) String() string {
+	s := hex.EncodeToString(u[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// Version returns UUID version.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns UUID version 7 timestamp or zero time for other versions.
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	return readMillis(u[:6])
+}
+
+// ULID represents universally unique lexicographically sortable identifier.
+type ULID [16]byte
+
+// NewULID generate ULID with current time.
+func NewULID() (ULID, error) {
+	var u ULID
+	if _, err := io.ReadFull(rand.Reader, u[6:]); err != nil {
+		return u, err
+	}
+	putMillis(u[:6], time.Now())
+	return u, nil
+}
+
+// ParseULID parse 26 characters crockford base32 ULID.
+// Hyphens are not allowed in ULID.
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, errors.New("invalid ulid length")
+	}
+	if strings.ContainsRune(s, '-') {
+		return u, errors.New("invalid ulid format")
+	}
+
+	var hi, lo uint64
+	for i, c := range normalizeCrockford(s) {
+		v := strings.IndexRune(crockfordAlphabet, c)
+		if v < 0 || (i == 0 && v > 7) {
+			return u, errors.New("invalid ulid format")
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u, nil
+}
+
+// String returns 26 characters crockford base32 form of ULID.
+func (u ULID) String() string {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+
+	res := make([]byte, 26)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = crockfordAlphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(res)
+}
+
+// Time returns ULID timestamp.
+func (u ULID) Time() time.Time {
+	return readMillis(u[:6])
+}
+
+// NewSortableID generate lexicographically sortable base62 id
+// with 9 characters time prefix and n random characters.
+func NewSortableID(n uint) (string, error) {
+	ms := time.Now().UnixMilli()
+	prefix := make([]byte, sortableTimeSize)
+	for i := len(prefix) - 1; i >= 0; i-- {
+		prefix[i] = base62Alphabet[ms%62]
+		ms /= 62
+	}
+
+	random, err := RandomStringFrom(rand.Reader, n, base62Alphabet)
+	if err != nil {
+		return "", err
+	}
+	return string(prefix) + random, nil
+}
+
+// ParseSortableID validate sortable id and returns its timestamp.
+// Timestamps after year 9999 are rejected.
+func ParseSortableID(id string) (time.Time, error) {
+	if len(id) < sortableTimeSize {
+		return time.Time{}, errors.New("invalid sortable id length")
+	}
+
+	var ms int64
+	for i, c := range id {
+		v := strings.IndexRune(base62Alphabet, c)
+		if v < 0 {
+			return time.Time{}, errors.New("invalid sortable id format")
+		}
+		if i < sortableTimeSize {
+			ms = ms*62 + int64(v)
+		}
+	}
+	if ms > sortableMaxMillis {
+		return time.Time{}, errors.New("invalid sortable id timestamp")
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// normalizeCrockford map crockford base32 aliases to canonical characters.
+func normalizeCrockford(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-':
+			return -1
+		case 'I', 'i', 'L', 'l':
+			return '1'
+		case 'O', 'o':
+			return '0'
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}, s)
+}
+
+// encodeBase encode data in alphabet base, leading zero bytes kept as first alphabet character.
+func encodeBase(data []byte, alphabet string) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	base := big.NewInt(int64(len(alphabet)))
+	num := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+
+	var res []byte
+	for num.Sign() > 0 {
+		num.DivMod(num, base, mod)
+		res = append(res, alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		res = append(res, alphabet[0])
+	}
+
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return string(res)
+}
+
+// decodeBase decode string encoded with encodeBase.
+func decodeBase(s string, alphabet string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	base := big.NewInt(int64(len(alphabet)))
+	num := new(big.Int)
+	for _, c := range s[zeros:] {
+		v := strings.IndexRune(alphabet, c)
+		if v < 0 {
+			return nil, errors.New("invalid token character")
+		}
+		num.Mul(num, base)
+		num.Add(num, big.NewInt(int64(v)))
+	}
+
+	return append(make([]byte, zeros), num.Bytes()...), nil
+}
+
+// putMillis write 48 bit unix milliseconds of t into dst.
+func putMillis(dst []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		dst[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// readMillis read 48 bit unix milliseconds from src.
+func readMillis(src []byte) time.Time {
+	var ms uint64
+	for _, b := range src[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms))
+}
@@ -0,0 +1,150 @@
+package goutils_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestEncodeToken(t *testing.T) {
+	data := []byte{0, 0, 1, 2, 3, 250, 251, 252}
+	tests := []struct {
+		encoding goutils.TokenEncoding
+		expected string
+	}{
+		{goutils.TokenHex, "0000010203fafbfc"},
+		{goutils.TokenBase32, "000020G3ZBXZR"},
+		{goutils.TokenBase58, "11W7N3REB"},
+		{goutils.TokenBase62, "00JVc9Qlw"},
+		{goutils.TokenBase64, "AAABAgP6-_w"},
+	}
+
+	for _, test := range tests {
+		result, err := goutils.EncodeToken(data, test.encoding)
+		if err != nil {
+			t.Fatalf("EncodeToken(%d) returned error: %v", test.encoding, err)
+		}
+		if result != test.expected {
+			t.Errorf("EncodeToken(%d) = %q; want %q", test.encoding, result, test.expected)
+		}
+
+		decoded, err := goutils.DecodeToken(result, test.encoding)
+		if err != nil {
+			t.Fatalf("DecodeToken(%q, %d) returned error: %v", result, test.encoding, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("DecodeToken(%q, %d) = %v; want %v", result, test.encoding, decoded, data)
+		}
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	for _, encoding := range []goutils.TokenEncoding{
+		goutils.TokenHex, goutils.TokenBase32, goutils.TokenBase58, goutils.TokenBase62, goutils.TokenBase64,
+	} {
+		token, err := goutils.GenerateToken(32, encoding)
+		if err != nil {
+			t.Fatalf("GenerateToken(32, %d) returned error: %v", encoding, err)
+		}
+		data, err := goutils.DecodeToken(token, encoding)
+		if err != nil || len(data) != 32 {
+			t.Errorf("DecodeToken(%q, %d) = %d bytes, %v; want 32 bytes", token, encoding, len(data), err)
+		}
+	}
+
+	if _, err := goutils.DecodeToken("0OIl", goutils.TokenBase58); err == nil {
+		t.Errorf("DecodeToken with invalid base58 characters should return error")
+	}
+}
+
+func TestUUID(t *testing.T) {
+	v4, err := goutils.NewUUIDv4()
+	if err != nil {
+		t.Fatalf("NewUUIDv4 returned error: %v", err)
+	}
+	if v4.Version() != 4 {
+		t.Errorf("NewUUIDv4().Version() = %d; want 4", v4.Version())
+	}
+
+	v7, err := goutils.NewUUIDv7()
+	if err != nil {
+		t.Fatalf("NewUUIDv7 returned error: %v", err)
+	}
+	if v7.Version() != 7 {
+		t.Errorf("NewUUIDv7().Version() = %d; want 7", v7.Version())
+	}
+	if time.Since(v7.Time()) > time.Minute {
+		t.Errorf("NewUUIDv7().Time() = %v; want current time", v7.Time())
+	}
+
+	parsed, err := goutils.ParseUUID(v7.String())
+	if err != nil || parsed != v7 {
+		t.Errorf("ParseUUID(%q) = %v, %v; want %v", v7.String(), parsed, err, v7)
+	}
+
+	for _, invalid := range []string{"", "123", "0190a5b2-7c1d-7e3f-8a4b-5c6d7e8f9a0", "0190a5b2x7c1d-7e3f-8a4b-5c6d7e8f9a0b"} {
+		if _, err := goutils.ParseUUID(invalid); err == nil {
+			t.Errorf("ParseUUID(%q) should return error", invalid)
+		}
+	}
+}
+
+func TestULID(t *testing.T) {
+	id, err := goutils.NewULID()
+	if err != nil {
+		t.Fatalf("NewULID returned error: %v", err)
+	}
+	if time.Since(id.Time()) > time.Minute {
+		t.Errorf("NewULID().Time() = %v; want current time", id.Time())
+	}
+
+	parsed, err := goutils.ParseULID(id.String())
+	if err != nil || parsed != id {
+		t.Errorf("ParseULID(%q) = %v, %v; want %v", id.String(), parsed, err, id)
+	}
+
+	known := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	parsed, err = goutils.ParseULID(known)
+	if err != nil || parsed.String() != known {
+		t.Errorf("ParseULID(%q).String() = %q, %v; want %q", known, parsed.String(), err, known)
+	}
+	if parsed.Time().UnixMilli() != 1469922850259 {
+		t.Errorf("ParseULID(%q).Time() = %d; want %d", known, parsed.Time().UnixMilli(), 1469922850259)
+	}
+
+	if _, err := goutils.ParseULID("81ARZ3NDEKTSV4RRFFQ69G5FAV"); err == nil {
+		t.Errorf("ParseULID with overflow should return error")
+	}
+	if _, err := goutils.ParseULID("01M575A0EG-YRCXYS5CQHVNEC7"); err == nil {
+		t.Errorf("ParseULID with hyphen should return error")
+	}
+}
+
+func TestSortableID(t *testing.T) {
+	first, err := goutils.NewSortableID(8)
+	if err != nil {
+		t.Fatalf("NewSortableID returned error: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	second, _ := goutils.NewSortableID(8)
+
+	if len(first) != 17 {
+		t.Errorf("NewSortableID(8) = %q; length = %d; want 17", first, len(first))
+	}
+	if first >= second {
+		t.Errorf("NewSortableID() not sortable: %q >= %q", first, second)
+	}
+
+	ts, err := goutils.ParseSortableID(first)
+	if err != nil || time.Since(ts) > time.Minute {
+		t.Errorf("ParseSortableID(%q) = %v, %v; want current time", first, ts, err)
+	}
+	if _, err := goutils.ParseSortableID("abc-"); err == nil {
+		t.Errorf("ParseSortableID with invalid id should return error")
+	}
+	if _, err := goutils.ParseSortableID("zzzzzzzzz"); err == nil {
+		t.Errorf("ParseSortableID with out of range timestamp should return error")
+	}
+}
@@ -0,0 +1,133 @@
+package goutils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations contains ascii form of lowercase non-ascii letters.
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'ø': "o", 'đ': "d", 'ł': "l", 'œ': "oe", 'þ': "th", 'ı': "i",
+
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g",
+
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+
+	// Persian and arabic
+	'ا': "a", 'آ': "a", 'أ': "a", 'إ': "e", 'ب': "b", 'پ': "p", 'ت': "t", 'ث': "s",
+	'ج': "j", 'چ': "ch", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "z", 'ر': "r", 'ز': "z",
+	'ژ': "zh", 'س': "s", 'ش': "sh", 'ص': "s", 'ض': "z", 'ط': "t", 'ظ': "z", 'ع': "",
+	'غ': "gh", 'ف': "f", 'ق': "gh", 'ک': "k", 'ك': "k", 'گ': "g", 'ل': "l", 'م': "m",
+	'ن': "n", 'و': "v", 'ه': "h", 'ة': "h", 'ی': "i", 'ي': "i", 'ى': "i", 'ئ': "y",
+	'ء': "", 'ـ': "", zwnj: "",
+}
+
+// Transliterate convert latin letters with diacritics, cyrillic, greek
+// and persian letters to ascii (e.g. "Café Москва کوروش" to "Cafe Moskva kurush").
+// Persian ا, و, ی and ه transliterated by position in word (e.g. "تهران نور" to "tehran nur"),
+// short vowels are not written in persian and not restored.
+// Persian and arabic-indic digits converted to ascii digits.
+// Characters without transliteration rule are kept as is.
+func Transliterate(s string) string {
+	var res strings.Builder
+	runes := []rune(ToEnglishDigits(s))
+	for i, r := range runes {
+		if r <= unicode.MaxASCII {
+			res.WriteRune(r)
+			continue
+		}
+
+		if v, ok := transliteratePersian(runes, i); ok {
+			res.WriteString(v)
+			continue
+		}
+
+		if v, ok := transliterate(r); ok {
+			res.WriteString(v)
+			continue
+		}
+
+		// Decompose and remove diacritics (e.g. é to e)
+		for _, d := range norm.NFD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+			if v, ok := transliterate(d); ok {
+				res.WriteString(v)
+			} else {
+				res.WriteRune(d)
+			}
+		}
+	}
+	return res.String()
+}
+
+// transliterate returns ascii form of rune with case preserved.
+func transliterate(r rune) (string, bool) {
+	v, ok := transliterations[unicode.ToLower(r)]
+	if !ok || v == "" || !unicode.IsUpper(r) {
+		return v, ok
+	}
+	return strings.ToUpper(v[:1]) + v[1:], true
+}
+
+// transliteratePersian returns ascii form of persian vowel letters and ه by position in word.
+// و and ی read as vowels between consonants and as consonants next to vowels.
+func transliteratePersian(runes []rune, i int) (string, bool) {
+	letter := func(j int) bool {
+		return j >= 0 && j < len(runes) && unicode.Is(unicode.Arabic, runes[j]) && unicode.IsLetter(runes[j])
+	}
+	vowel := func(j int) bool {
+		return letter(j) && strings.ContainsRune("اآویيى", runes[j])
+	}
+	consonant := func(j int) bool {
+		return letter(j) && !vowel(j)
+	}
+	initialAlef := func(j int) bool {
+		return letter(j) && runes[j] == 'ا' && !letter(j-1)
+	}
+
+	switch runes[i] {
+	case 'ا':
+		if !letter(i-1) && i+1 < len(runes) && strings.ContainsRune("ویيى", runes[i+1]) {
+			return "", true // carrier of initial vowel (e.g. ایران, او)
+		}
+		return "a", true
+	case 'و':
+		switch {
+		case initialAlef(i - 1):
+			return "u", true
+		case consonant(i-1) && consonant(i+1):
+			return "u", true
+		case consonant(i-1) && !letter(i+1):
+			return "o", true
+		}
+		return "v", true
+	case 'ی', 'ي', 'ى':
+		if initialAlef(i-1) || (consonant(i-1) && !vowel(i+1)) {
+			return "i", true
+		}
+		return "y", true
+	case 'ه', 'ة':
+		switch {
+		case consonant(i-1) && consonant(i+1):
+			return "eh", true
+		case consonant(i-1) && !letter(i+1):
+			return "e", true
+		}
+		return "h", true
+	}
+	return "", false
+}
@@ -0,0 +1,32 @@
+package goutils_test
+
+import (
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Café Münch", "Cafe Munch"},
+		{"Straße Øresund Łódź", "Strasse Oresund Lodz"},
+		{"Москва Щука", "Moskva Shchuka"},
+		{"Αθήνα", "Athina"},
+		{"تهران ۱۴۰۳", "tehran 1403"},
+		{"سلام نور کوروش", "slam nur kurush"},
+		{"ایران او خیابان", "iran u khyaban"},
+		{"مهدی خسرو خانه کوه", "mehdi khsro khane kuh"},
+		{"یک دو", "yk do"},
+		{"日本", "日本"},
+	}
+
+	for _, test := range tests {
+		result := goutils.Transliterate(test.input)
+		if result != test.expected {
+			t.Errorf("Transliterate(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package goutils
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// URLBuilder build escaped absolute and root relative urls from base url.
+// URLBuilder is immutable, each method returns new builder
+// so base builders can be shared between goroutines.
+type URLBuilder struct {
+	scheme   string
+	host     string
+	segments []string
+	query    url.Values
+	fragment string
+	err      error
+}
+
+// NewURLBuilder create builder from absolute (e.g. https://cdn.example.com/uploads)
+// or root relative (e.g. /uploads) base url.
+//
+// code block:
+//
+//	cdn, _ := NewURLBuilder("https://cdn.example.com/uploads")
+//	link, _ := cdn.Path("avatars", "عکس من.png").Set("size", 128).Absolute()
+//
+// output:
+//
+//	https://cdn.example.com/uploads/avatars/%D8%B9%DA%A9%D8%B3%20%D9%85%D9%86.png?size=128
+func NewURLBuilder(base string) (*URLBuilder, error) {
+	u, err := url.Parse(NormalizeURL(base))
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme == "") != (u.Host == "") || u.Opaque != "" {
+		return nil, fmt.Errorf("invalid base url %q", base)
+	}
+
+	b := &URLBuilder{
+		scheme:   u.Scheme,
+		host:     u.Host,
+		query:    u.Query(),
+		fragment: u.Fragment,
+	}
+	b = b.Path(u.Path)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b, nil
+}
+
+// Path append path segments to url. Segments split by slash and empty segments ignored.
+// Absolute and Relative returns ErrInvalidPath for "." and ".." segments.
+func (b *URLBuilder) Path(segments ...string) *URLBuilder {
+	res := b.clone()
+	for _, segment := range segments {
+		for _, part := range strings.Split(segment, "/") {
+			switch part {
+			case "":
+			case ".", "..":
+				if res.err == nil {
+					res.err = fmt.Errorf("%w: %q segment", ErrInvalidPath, part)
+				}
+			default:
+				res.segments = append(res.segments, part)
+			}
+		}
+	}
+	return res
+}
+
+// File append path of file relative to root directory (see RelativeURL).
+// Absolute and Relative returns ErrPathEscapesRoot if path is not under root.
+func (b *URLBuilder) File(root string, path ...string) *URLBuilder {
+	rel, err := RelativeURL(root, path...)
+	if err != nil {
+		res := b.clone()
+		if res.err == nil {
+			res.err = err
+		}
+		return res
+	}
+	return b.Path(rel)
+}
+
+// Set replace query parameter values.
+func (b *URLBuilder) Set(key string, values ...any) *URLBuilder {
+	res := b.clone()
+	res.query.Del(key)
+	for _, v := range values {
+		res.query.Add(key, queryValue(v))
+	}
+	return res
+}
+
+// Add append values to query parameter.
+func (b *URLBuilder) Add(key string, values ...any) *URLBuilder {
+	res := b.clone()
+	for _, v := range values {
+		res.query.Add(key, queryValue(v))
+	}
+	return res
+}
+
+// Remove delete query parameters.
+func (b *URLBuilder) Remove(keys ...string) *URLBuilder {
+	res := b.clone()
+	for _, key := range keys {
+		res.query.Del(key)
+	}
+	return res
+}
+
+// SetQuery replace query parameters from map with string keys or struct.
+// Struct fields encoded using `query:"name,omitempty"` tag, use "-" to skip field.
+// Slice values encoded as multiple parameters and nil values ignored.
+func (b *URLBuilder) SetQuery(v any) *URLBuilder {
+	return b.mergeQuery(v, true)
+}
+
+// AddQuery append query parameters from map with string keys or struct (see SetQuery).
+func (b *URLBuilder) AddQuery(v any) *URLBuilder {
+	return b.mergeQuery(v, false)
+}
+
+// Fragment set url fragment, pass empty string to remove fragment.
+func (b *URLBuilder) Fragment(fragment string) *URLBuilder {
+	res := b.clone()
+	res.fragment = fragment
+	return res
+}
+
+// Absolute returns absolute url. Returns error if base url is relative,
+// file is not under root or query parameters are not encodable.
+func (b *URLBuilder) Absolute() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if b.host == "" {
+		return "", fmt.Errorf("url builder has no scheme and host")
+	}
+	return b.scheme + "://" + b.host + b.relative(), nil
+}
+
+// Relative returns root relative url (e.g. /uploads/file.png?size=128).
+func (b *URLBuilder) Relative() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	return b.relative(), nil
+}
+
+// relative returns escaped path, query and fragment.
+func (b *URLBuilder) relative() string {
+	var res strings.Builder
+	for _, segment := range b.segments {
+		res.WriteString("/")
+		res.WriteString(url.PathEscape(segment))
+	}
+	if res.Len() == 0 {
+		res.WriteString("/")
+	}
+	if len(b.query) > 0 {
+		res.WriteString("?")
+		res.WriteString(b.query.Encode())
+	}
+	if b.fragment != "" {
+		res.WriteString("#")
+		res.WriteString((&url.URL{Fragment: b.fragment}).EscapedFragment())
+	}
+	return res.String()
+}
+
+// clone returns deep copy of builder.
+func (b *URLBuilder) clone() *URLBuilder {
+	res := *b
+	res.segments = append([]string(nil), b.segments...)
+	res.query = make(url.Values, len(b.query))
+	for k, v := range b.query {
+		res.query[k] = append([]string(nil), v...)
+	}
+	return &res
+}
+
+// mergeQuery set or add query parameters from map or struct.
+func (b *URLBuilder) mergeQuery(v any, replace bool) *URLBuilder {
+	res := b.clone()
+	values, err := queryValues(v)
+	if err != nil {
+		if res.err == nil {
+			res.err = err
+		}
+		return res
+	}
+
+	for key, items := range values {
+		if replace {
+			res.query.Del(key)
+		}
+		for _, item := range items {
+			res.query.Add(key, item)
+		}
+	}
+	return res
+}
+
+// queryValues convert map with string keys or struct to query values.
+func queryValues(v any) (url.Values, error) {
+	if values, ok := v.(url.Values); ok {
+		return values, nil
+	}
+
+	res := make(url.Values)
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("query map key must be string, got %s", rv.Type().Key())
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			appendQueryValue(res, iter.Key().String(), iter.Value())
+		}
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(field.Tag.Get("query"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if opts == "omitempty" && rv.Field(i).IsZero() {
+				continue
+			}
+			appendQueryValue(res, name, rv.Field(i))
+		}
+	case reflect.Invalid:
+	default:
+		return nil, fmt.Errorf("unsupported query type %T", v)
+	}
+	return res, nil
+}
+
+// appendQueryValue add value to query, slices added as multiple values.
+func appendQueryValue(values url.Values, key string, v reflect.Value) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < v.Len(); i++ {
+			appendQueryValue(values, key, v.Index(i))
+		}
+		return
+	}
+	values.Add(key, queryValue(v.Interface()))
+}
+
+// queryValue returns string representation of query value.
+func queryValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,104 @@
+package goutils_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestURLBuilder(t *testing.T) {
+	cdn, err := goutils.NewURLBuilder("HTTPS://CDN.Example.com/uploads/")
+	if err != nil {
+		t.Fatalf("NewURLBuilder() error = %v", err)
+	}
+
+	tests := []struct {
+		builder  *goutils.URLBuilder
+		absolute string
+		relative string
+	}{
+		{cdn, "https://cdn.example.com/uploads", "/uploads"},
+		{
+			cdn.Path("avatars", "عکس من.png"),
+			"https://cdn.example.com/uploads/avatars/%D8%B9%DA%A9%D8%B3%20%D9%85%D9%86.png",
+			"/uploads/avatars/%D8%B9%DA%A9%D8%B3%20%D9%85%D9%86.png",
+		},
+		{cdn.Path("a/b", "/c/", "d?e"), "https://cdn.example.com/uploads/a/b/c/d%3Fe", "/uploads/a/b/c/d%3Fe"},
+		{cdn.Path("f").Set("size", 128).Add("tag", "a", "b"), "https://cdn.example.com/uploads/f?size=128&tag=a&tag=b", "/uploads/f?size=128&tag=a&tag=b"},
+		{cdn.Set("a", 1).Set("b", 2).Remove("a"), "https://cdn.example.com/uploads?b=2", "/uploads?b=2"},
+		{cdn.Fragment("بخش 1"), "https://cdn.example.com/uploads#%D8%A8%D8%AE%D8%B4%201", "/uploads#%D8%A8%D8%AE%D8%B4%201"},
+		{cdn.File("/var/www/uploads", "/var/www/uploads/docs/file.pdf"), "https://cdn.example.com/uploads/docs/file.pdf", "/uploads/docs/file.pdf"},
+	}
+
+	for _, test := range tests {
+		if result, err := test.builder.Absolute(); err != nil || result != test.absolute {
+			t.Errorf("Absolute() = %q, %v; want %q", result, err, test.absolute)
+		}
+		if result, err := test.builder.Relative(); err != nil || result != test.relative {
+			t.Errorf("Relative() = %q, %v; want %q", result, err, test.relative)
+		}
+	}
+
+	// Base must not be changed by derived builders
+	if result, _ := cdn.Relative(); result != "/uploads" {
+		t.Errorf("base builder changed to %q", result)
+	}
+}
+
+func TestURLBuilderQuery(t *testing.T) {
+	type filter struct {
+		Page    int      `query:"page"`
+		Tags    []string `query:"tag"`
+		Search  string   `query:"q,omitempty"`
+		Since   *time.Time
+		Secret  string `query:"-"`
+		private string
+	}
+
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	base, _ := goutils.NewURLBuilder("/search?page=1&sort=new")
+
+	tests := []struct {
+		builder  *goutils.URLBuilder
+		expected string
+	}{
+		{base.SetQuery(filter{Page: 2, Tags: []string{"go", "web"}, Secret: "x", private: "y"}), "/search?page=2&sort=new&tag=go&tag=web"},
+		{base.SetQuery(&filter{Page: 3, Search: "سلام", Since: &since}), "/search?Since=2025-01-02T03%3A04%3A05Z&page=3&q=%D8%B3%D9%84%D8%A7%D9%85&sort=new"},
+		{base.AddQuery(map[string]any{"page": 5, "empty": nil}), "/search?page=1&page=5&sort=new"},
+		{base.SetQuery(map[string][]int{"page": {7, 8}}), "/search?page=7&page=8&sort=new"},
+	}
+
+	for _, test := range tests {
+		if result, err := test.builder.Relative(); err != nil || result != test.expected {
+			t.Errorf("Relative() = %q, %v; want %q", result, err, test.expected)
+		}
+	}
+
+	if _, err := base.SetQuery(42).Relative(); err == nil {
+		t.Errorf("SetQuery(42) expected error")
+	}
+	if _, err := base.Absolute(); err == nil {
+		t.Errorf("Absolute() on relative base expected error")
+	}
+	if _, err := base.File("/var/www", "/etc/passwd").Relative(); !errors.Is(err, goutils.ErrPathEscapesRoot) {
+		t.Errorf("File() outside root error = %v; want %v", err, goutils.ErrPathEscapesRoot)
+	}
+	for _, segments := range [][]string{{"..", "..", "admin"}, {"a/../b"}, {"./a"}} {
+		if _, err := base.Path(segments...).Relative(); !errors.Is(err, goutils.ErrInvalidPath) {
+			t.Errorf("Path(%q) error = %v; want %v", segments, err, goutils.ErrInvalidPath)
+		}
+	}
+	if result, err := base.Path("..a", "b..", ".c").Relative(); err != nil || result != "/search/..a/b../.c?page=1&sort=new" {
+		t.Errorf("Path() with dotted names = %q, %v; want %q", result, err, "/search/..a/b../.c?page=1&sort=new")
+	}
+}
+
+func TestNewURLBuilderInvalid(t *testing.T) {
+	for _, base := range []string{"https:///path", "mailto:john@example.com", "http://[::1", "https://cdn.example.com/a/../b"} {
+		if _, err := goutils.NewURLBuilder(base); err == nil {
+			t.Errorf("NewURLBuilder(%q) expected error", base)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package goutils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Signed url verification errors.
+var (
+	ErrURLExpired    = errors.New("url expired")
+	ErrURLTampered   = errors.New("url signature mismatch")
+	ErrURLUnknownKey = errors.New("unknown url signing key")
+)
+
+// Signed url query parameters.
+const (
+	urlExpiresParam   = "exp"
+	urlKeyIDParam     = "kid"
+	urlSignatureParam = "sig"
+)
+
+// URLSigner sign and verify expiring urls with HMAC-SHA256.
+// Signature covers escaped path and sorted query, scheme and host are not signed.
+// URLSigner is safe for concurrent use.
+type URLSigner struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewURLSigner create signer that sign urls with current key and verify with all keys.
+// Key id added to signed urls as kid parameter to support key rotation,
+// empty key id omit kid parameter.
+func NewURLSigner(current string, keys map[string][]byte) (*URLSigner, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrURLUnknownKey, current)
+	}
+
+	signer := &URLSigner{current: current, keys: make(map[string][]byte, len(keys))}
+	for kid, secret := range keys {
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("empty secret for url signing key %q", kid)
+		}
+		signer.keys[kid] = append([]byte(nil), secret...)
+	}
+	return signer, nil
+}
+
+// Sign returns url with expiration, key id and signature parameters.
+// Existing signature parameters are replaced.
+func (s *URLSigner) Sign(rawURL string, expiry time.Duration) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Del(urlSignatureParam)
+	query.Del(urlKeyIDParam)
+	query.Set(urlExpiresParam, strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	if s.current != "" {
+		query.Set(urlKeyIDParam, s.current)
+	}
+
+	query.Set(urlSignatureParam, signURL(s.keys[s.current], u.EscapedPath(), query))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verify check url signature and expiration.
+// Returns ErrURLUnknownKey, ErrURLTampered or ErrURLExpired on failure.
+// In http handlers pass r.URL.RequestURI() or r.URL.String().
+func (s *URLSigner) Verify(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ErrURLTampered
+	}
+
+	query := u.Query()
+	secret, ok := s.keys[query.Get(urlKeyIDParam)]
+	if !ok {
+		return ErrURLUnknownKey
+	}
+
+	signature := query.Get(urlSignatureParam)
+	query.Del(urlSignatureParam)
+	expected := signURL(secret, u.EscapedPath(), query)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrURLTampered
+	}
+
+	expires, err := strconv.ParseInt(query.Get(urlExpiresParam), 10, 64)
+	if err != nil {
+		return ErrURLTampered
+	}
+	if time.Now().Unix() > expires {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+// SignURL sign url with single secret (see URLSigner).
+//
+// code block:
+//
+//	path, _ := AbsoluteURL("/var/www", "/var/www/files/report.pdf")
+//	link, _ := SignURL(path, secret, time.Hour)
+//	err := VerifyURL(link, secret)
+//
+// output:
+//
+//	/files/report.pdf?exp=1735689600&sig=...
+func SignURL(rawURL string, secret []byte, expiry time.Duration) (string, error) {
+	signer, err := NewURLSigner("", map[string][]byte{"": secret})
+	if err != nil {
+		return "", err
+	}
+	return signer.Sign(rawURL, expiry)
+}
+
+// VerifyURL verify url signed by SignURL.
+func VerifyURL(rawURL string, secret []byte) error {
+	signer, err := NewURLSigner("", map[string][]byte{"": secret})
+	if err != nil {
+		return err
+	}
+	return signer.Verify(rawURL)
+}
+
+// signURL returns base64 HMAC-SHA256 of path and sorted query.
+func signURL(secret []byte, path string, query url.Values) string {
+	if path == "" {
+		path = "/"
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(path + "?" + query.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,95 @@
+package goutils_test
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestSignURL(t *testing.T) {
+	secret := []byte("secret")
+	link, err := goutils.SignURL("https://cdn.example.com/files/گزارش.pdf?download=1", secret, time.Hour)
+	if err != nil {
+		t.Fatalf("SignURL() error = %v", err)
+	}
+	if err := goutils.VerifyURL(link, secret); err != nil {
+		t.Errorf("VerifyURL(%q) = %v; want nil", link, err)
+	}
+
+	// Host is not signed, path and query are
+	if err := goutils.VerifyURL(strings.Replace(link, "cdn.example.com", "mirror.example.com", 1), secret); err != nil {
+		t.Errorf("VerifyURL() on other host = %v; want nil", err)
+	}
+
+	tampered := []string{
+		strings.Replace(link, "files", "private", 1),
+		strings.Replace(link, "download=1", "download=2", 1),
+		link + "&extra=1",
+		strings.Replace(link, "sig=", "sig=x", 1),
+	}
+	for _, input := range tampered {
+		if err := goutils.VerifyURL(input, secret); !errors.Is(err, goutils.ErrURLTampered) {
+			t.Errorf("VerifyURL(%q) = %v; want %v", input, err, goutils.ErrURLTampered)
+		}
+	}
+	if err := goutils.VerifyURL(link, []byte("other")); !errors.Is(err, goutils.ErrURLTampered) {
+		t.Errorf("VerifyURL() with other secret = %v; want %v", err, goutils.ErrURLTampered)
+	}
+
+	expired, _ := goutils.SignURL("/files/a.pdf", secret, -time.Minute)
+	if err := goutils.VerifyURL(expired, secret); !errors.Is(err, goutils.ErrURLExpired) {
+		t.Errorf("VerifyURL(%q) = %v; want %v", expired, err, goutils.ErrURLExpired)
+	}
+
+	// Extending expiration invalidates signature
+	u, _ := url.Parse(expired)
+	query := u.Query()
+	query.Set("exp", "9999999999")
+	u.RawQuery = query.Encode()
+	if err := goutils.VerifyURL(u.String(), secret); !errors.Is(err, goutils.ErrURLTampered) {
+		t.Errorf("VerifyURL() with extended exp = %v; want %v", err, goutils.ErrURLTampered)
+	}
+}
+
+func TestURLSignerRotation(t *testing.T) {
+	old, err := goutils.NewURLSigner("v1", map[string][]byte{"v1": []byte("old")})
+	if err != nil {
+		t.Fatalf("NewURLSigner() error = %v", err)
+	}
+	rotated, err := goutils.NewURLSigner("v2", map[string][]byte{"v1": []byte("old"), "v2": []byte("new")})
+	if err != nil {
+		t.Fatalf("NewURLSigner() error = %v", err)
+	}
+
+	oldLink, _ := old.Sign("/files/a.pdf", time.Hour)
+	newLink, _ := rotated.Sign("/files/a.pdf", time.Hour)
+	if !strings.Contains(newLink, "kid=v2") {
+		t.Errorf("Sign() = %q; want kid=v2", newLink)
+	}
+	if err := rotated.Verify(oldLink); err != nil {
+		t.Errorf("Verify(%q) = %v; want nil", oldLink, err)
+	}
+	if err := rotated.Verify(newLink); err != nil {
+		t.Errorf("Verify(%q) = %v; want nil", newLink, err)
+	}
+	if err := old.Verify(newLink); !errors.Is(err, goutils.ErrURLUnknownKey) {
+		t.Errorf("Verify(%q) = %v; want %v", newLink, err, goutils.ErrURLUnknownKey)
+	}
+
+	// Re-signing replaces previous signature parameters
+	resigned, _ := rotated.Sign(oldLink, time.Hour)
+	if strings.Count(resigned, "sig=") != 1 || !strings.Contains(resigned, "kid=v2") {
+		t.Errorf("Sign(%q) = %q; want single v2 signature", oldLink, resigned)
+	}
+
+	if _, err := goutils.NewURLSigner("v3", map[string][]byte{"v1": []byte("old")}); !errors.Is(err, goutils.ErrURLUnknownKey) {
+		t.Errorf("NewURLSigner() with missing current key = %v; want %v", err, goutils.ErrURLUnknownKey)
+	}
+	if _, err := goutils.NewURLSigner("v1", map[string][]byte{"v1": nil}); err == nil {
+		t.Errorf("NewURLSigner() with empty secret expected error")
+	}
+}
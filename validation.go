@@ -0,0 +1,271 @@
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Validation failure reasons, use errors.Is to check ValidationError reason.
+var (
+	ErrInvalidLength   = errors.New("invalid length")
+	ErrInvalidFormat   = errors.New("invalid format")
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrInvalidPrefix   = errors.New("invalid prefix")
+)
+
+// ValidationError represents validation failure of value.
+type ValidationError struct {
+	Kind  string
+	Value string
+	Err   error
+}
+
+// Error returns validation error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %v", e.Kind, e.Value, e.Err)
+}
+
+// Unwrap returns validation failure reason.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// NormalizeNationalCode remove separators, convert digits to ascii
+// and pad 8 or 9 digits codes with leading zeros.
+func NormalizeNationalCode(s string) string {
+	s = cleanIdentifier(s)
+	if len(s) == 8 || len(s) == 9 {
+		s = strings.Repeat("0", 10-len(s)) + s
+	}
+	return s
+}
+
+// ValidateNationalCode validate iranian national code (کد ملی) checksum.
+func ValidateNationalCode(s string) error {
+	code := NormalizeNationalCode(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "national code", Value: s, Err: err}
+	}
+
+	if !isDigits(code) {
+		return fail(ErrInvalidFormat)
+	}
+	if len(code) != 10 {
+		return fail(ErrInvalidLength)
+	}
+	if strings.Count(code, code[:1]) == 10 {
+		return fail(ErrInvalidFormat)
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(code[i]-'0') * (10 - i)
+	}
+	check, r := int(code[9]-'0'), sum%11
+	if (r < 2 && check != r) || (r >= 2 && check != 11-r) {
+		return fail(ErrInvalidChecksum)
+	}
+	return nil
+}
+
+// IsNationalCode check if string is valid iranian national code.
+func IsNationalCode(s string) bool {
+	return ValidateNationalCode(s) == nil
+}
+
+// NormalizeCardNumber remove separators and convert digits to ascii.
+func NormalizeCardNumber(s string) string {
+	return cleanIdentifier(s)
+}
+
+// ValidateCardNumber validate 16 digits bank card number with luhn checksum.
+func ValidateCardNumber(s string) error {
+	card := NormalizeCardNumber(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "card number", Value: s, Err: err}
+	}
+
+	if !isDigits(card) {
+		return fail(ErrInvalidFormat)
+	}
+	if len(card) != 16 {
+		return fail(ErrInvalidLength)
+	}
+
+	sum := 0
+	for i := len(card) - 1; i >= 0; i-- {
+		d := int(card[i] - '0')
+		if (len(card)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return fail(ErrInvalidChecksum)
+	}
+	return nil
+}
+
+// IsCardNumber check if string is valid bank card number.
+func IsCardNumber(s string) bool {
+	return ValidateCardNumber(s) == nil
+}
+
+// FormatCardNumber returns card number in 4 digits groups (e.g. 6037 9911 2345 1234).
+func FormatCardNumber(s string) (string, error) {
+	if err := ValidateCardNumber(s); err != nil {
+		return "", err
+	}
+	return FormatRx(NormalizeCardNumber(s), `(\d{4})(\d{4})(\d{4})(\d{4})`, "$1 $2 $3 $4")
+}
+
+// NormalizeSheba remove separators, convert digits to ascii and uppercase country code.
+func NormalizeSheba(s string) string {
+	return strings.ToUpper(cleanIdentifier(s))
+}
+
+// ValidateSheba validate iranian sheba (IBAN) number with mod-97 checksum.
+func ValidateSheba(s string) error {
+	sheba := NormalizeSheba(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "sheba", Value: s, Err: err}
+	}
+
+	if !strings.HasPrefix(sheba, "IR") {
+		return fail(ErrInvalidPrefix)
+	}
+	if !isDigits(sheba[2:]) {
+		return fail(ErrInvalidFormat)
+	}
+	if len(sheba) != 26 {
+		return fail(ErrInvalidLength)
+	}
+
+	// Move country code and check digits to end, I = 18 and R = 27
+	mod := 0
+	for _, r := range sheba[4:] + "1827" + sheba[2:4] {
+		mod = (mod*10 + int(r-'0')) % 97
+	}
+	if mod != 1 {
+		return fail(ErrInvalidChecksum)
+	}
+	return nil
+}
+
+// IsSheba check if string is valid iranian sheba number.
+func IsSheba(s string) bool {
+	return ValidateSheba(s) == nil
+}
+
+// FormatSheba returns sheba in 4 characters groups (e.g. IR06 2960 0000 0010 0032 4200 01).
+func FormatSheba(s string) (string, error) {
+	if err := ValidateSheba(s); err != nil {
+		return "", err
+	}
+	return FormatRx(
+		NormalizeSheba(s),
+		`(IR\d{2})(\d{4})(\d{4})(\d{4})(\d{4})(\d{4})(\d{2})`,
+		"$1 $2 $3 $4 $5 $6 $7",
+	)
+}
+
+// NormalizeIranianPostalCode remove separators and convert digits to ascii.
+func NormalizeIranianPostalCode(s string) string {
+	return cleanIdentifier(s)
+}
+
+// ValidateIranianPostalCode validate 10 digits iranian postal code.
+// First five digits can not contain 0 or 2, fifth digit can not be 5,
+// last five digits can not contain 2 and first four digits can not be same.
+func ValidateIranianPostalCode(s string) error {
+	code := NormalizeIranianPostalCode(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "postal code", Value: s, Err: err}
+	}
+
+	if !isDigits(code) {
+		return fail(ErrInvalidFormat)
+	}
+	if len(code) != 10 {
+		return fail(ErrInvalidLength)
+	}
+	if strings.ContainsAny(code[:5], "02") || code[4] == '5' ||
+		strings.Contains(code[5:], "2") || strings.Count(code[:4], code[:1]) == 4 {
+		return fail(ErrInvalidFormat)
+	}
+	return nil
+}
+
+// IsIranianPostalCode check if string is valid iranian postal code.
+func IsIranianPostalCode(s string) bool {
+	return ValidateIranianPostalCode(s) == nil
+}
+
+// NormalizeIranianMobile remove separators, convert digits to ascii
+// and replace +98, 0098 and 98 prefixes with 0 (e.g. +98 912 123 4567 to 09121234567).
+func NormalizeIranianMobile(s string) string {
+	mobile := cleanIdentifier(s)
+	switch {
+	case strings.HasPrefix(mobile, "+98"):
+		mobile = "0" + mobile[3:]
+	case strings.HasPrefix(mobile, "0098"):
+		mobile = "0" + mobile[4:]
+	case strings.HasPrefix(mobile, "98") && len(mobile) == 12:
+		mobile = "0" + mobile[2:]
+	case strings.HasPrefix(mobile, "9") && len(mobile) == 10:
+		mobile = "0" + mobile
+	}
+	return mobile
+}
+
+// ValidateIranianMobile validate iranian mobile number.
+func ValidateIranianMobile(s string) error {
+	mobile := NormalizeIranianMobile(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "mobile", Value: s, Err: err}
+	}
+
+	if !isDigits(mobile) {
+		return fail(ErrInvalidFormat)
+	}
+	if len(mobile) != 11 {
+		return fail(ErrInvalidLength)
+	}
+	if !strings.HasPrefix(mobile, "09") {
+		return fail(ErrInvalidPrefix)
+	}
+	return nil
+}
+
+// IsIranianMobile check if string is valid iranian mobile number.
+func IsIranianMobile(s string) bool {
+	return ValidateIranianMobile(s) == nil
+}
+
+// FormatIranianMobile returns normalized mobile number in display format (e.g. 0912 123 4567).
+func FormatIranianMobile(s string) (string, error) {
+	if err := ValidateIranianMobile(s); err != nil {
+		return "", err
+	}
+	return FormatRx(NormalizeIranianMobile(s), `(\d{4})(\d{3})(\d{4})`, "$1 $2 $3")
+}
+
+// cleanIdentifier convert digits to ascii and remove whitespaces, dashes and parentheses.
+func cleanIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' || r == '(' || r == ')' {
+			return -1
+		}
+		return r
+	}, ToEnglishDigits(s))
+}
+
+// isDigits check if string is non-empty and contains only ascii digits.
+func isDigits(s string) bool {
+	return s != "" && ExtractNumbers(s) == s
+}
@@ -0,0 +1,143 @@
+package goutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mekramy/goutils"
+)
+
+func TestValidateNationalCode(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"0012345679", nil},
+		{"۰۰۱۲۳۴۵۶۷۹", nil},
+		{"499-370899-8", nil},
+		{"12345679", nil},
+		{"0012345678", goutils.ErrInvalidChecksum},
+		{"1111111111", goutils.ErrInvalidFormat},
+		{"00123a5679", goutils.ErrInvalidFormat},
+		{"1234567", goutils.ErrInvalidLength},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateNationalCode(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateNationalCode(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+
+	var verr *goutils.ValidationError
+	if err := goutils.ValidateNationalCode("0012345678"); !errors.As(err, &verr) || verr.Kind != "national code" {
+		t.Errorf("ValidateNationalCode() error = %v; want ValidationError", err)
+	}
+	if !goutils.IsNationalCode("0012345679") || goutils.IsNationalCode("0012345678") {
+		t.Errorf("IsNationalCode returned unexpected result")
+	}
+}
+
+func TestValidateCardNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"6037991123451233", nil},
+		{"6037-9911-2345-1233", nil},
+		{"۶۰۳۷ ۹۹۱۱ ۲۳۴۵ ۱۲۳۳", nil},
+		{"6037991123451234", goutils.ErrInvalidChecksum},
+		{"603799112345123", goutils.ErrInvalidLength},
+		{"6037x91123451233", goutils.ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateCardNumber(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateCardNumber(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+
+	formatted, err := goutils.FormatCardNumber("۶۰۳۷۹۹۱۱۲۳۴۵۱۲۳۳")
+	if err != nil || formatted != "6037 9911 2345 1233" {
+		t.Errorf("FormatCardNumber() = %q, %v; want %q", formatted, err, "6037 9911 2345 1233")
+	}
+}
+
+func TestValidateSheba(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"IR050170000000123456789012", nil},
+		{"ir05 0170 0000 0012 3456 7890 12", nil},
+		{"IR060170000000123456789012", goutils.ErrInvalidChecksum},
+		{"DE050170000000123456789012", goutils.ErrInvalidPrefix},
+		{"IR0501700000001234567890", goutils.ErrInvalidLength},
+		{"IR05017000000012345678901X", goutils.ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateSheba(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateSheba(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+
+	formatted, err := goutils.FormatSheba("ir050170000000123456789012")
+	if err != nil || formatted != "IR05 0170 0000 0012 3456 7890 12" {
+		t.Errorf("FormatSheba() = %q, %v; want %q", formatted, err, "IR05 0170 0000 0012 3456 7890 12")
+	}
+}
+
+func TestValidateIranianPostalCode(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"1193653471", nil},
+		{"11936-53471", nil},
+		{"1093653471", goutils.ErrInvalidFormat},
+		{"1193553471", goutils.ErrInvalidFormat},
+		{"1193653472", goutils.ErrInvalidFormat},
+		{"1111653471", goutils.ErrInvalidFormat},
+		{"119365347", goutils.ErrInvalidLength},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateIranianPostalCode(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateIranianPostalCode(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+}
+
+func TestIranianMobile(t *testing.T) {
+	for _, input := range []string{"09121234567", "+989121234567", "00989121234567", "989121234567", "9121234567", "+98 (912) 123-4567", "۰۹۱۲۱۲۳۴۵۶۷"} {
+		if result := goutils.NormalizeIranianMobile(input); result != "09121234567" {
+			t.Errorf("NormalizeIranianMobile(%q) = %q; want %q", input, result, "09121234567")
+		}
+		if !goutils.IsIranianMobile(input) {
+			t.Errorf("IsIranianMobile(%q) = false; want true", input)
+		}
+	}
+
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"08121234567", goutils.ErrInvalidPrefix},
+		{"0912123456", goutils.ErrInvalidLength},
+		{"0912123456a", goutils.ErrInvalidFormat},
+	}
+	for _, test := range tests {
+		if err := goutils.ValidateIranianMobile(test.input); !errors.Is(err, test.err) {
+			t.Errorf("ValidateIranianMobile(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+
+	formatted, err := goutils.FormatIranianMobile("+989121234567")
+	if err != nil || formatted != "0912 123 4567" {
+		t.Errorf("FormatIranianMobile() = %q, %v; want %q", formatted, err, "0912 123 4567")
+	}
+}
@@ -1,43 +1,424 @@
 package goutils
 
 import (
-	"html"
-	"path/filepath"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
-	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/idna"
 )
 
+// blockTagRx matches html line break and block level tags.
+var blockTagRx = regexp.MustCompile(`(?i)</?(br|p|div|li|ul|ol|h[1-6]|tr|td|th|blockquote|pre|section|article|header|footer)\b[^>]*>`)
+
 // RelativeURL returns the relative URL path of
 // file with respect to the root directory.
-func RelativeURL(root string, path ...string) string {
+// Returns ErrPathEscapesRoot if path is not under root.
+// Empty and "." root match all relative paths.
+func RelativeURL(root string, path ...string) (string, error) {
 	normalizedRoot := NormalizePath(root)
 	normalizedPath := NormalizePath(path...)
-	relPath := strings.TrimPrefix(normalizedPath, normalizedRoot)
-	relPath = strings.TrimPrefix(relPath, string(filepath.Separator))
-	return strings.TrimPrefix(filepath.ToSlash(relPath), "/")
+	if normalizedPath == normalizedRoot {
+		return "", nil
+	}
+
+	if normalizedRoot == "." {
+		if normalizedPath == ".." || strings.HasPrefix(normalizedPath, "../") ||
+			strings.HasPrefix(normalizedPath, "/") || windowsVolumeRx.MatchString(normalizedPath) {
+			return "", ErrPathEscapesRoot
+		}
+		return normalizedPath, nil
+	}
+
+	prefix := strings.TrimSuffix(normalizedRoot, "/") + "/"
+	if !strings.HasPrefix(normalizedPath, prefix) {
+		return "", ErrPathEscapesRoot
+	}
+	return strings.TrimPrefix(normalizedPath, prefix), nil
 }
 
 // AbsoluteURL returns the absolute URL path of
 // file with respect to the root directory.
-func AbsoluteURL(root string, path ...string) string {
-	return "/" + RelativeURL(root, path...)
+// Returns ErrPathEscapesRoot if path is not under root.
+func AbsoluteURL(root string, path ...string) (string, error) {
+	rel, err := RelativeURL(root, path...)
+	if err != nil {
+		return "", err
+	}
+	return "/" + rel, nil
 }
 
-// SanitizeRaw sanitize input to raw text.
+// SanitizeRaw sanitize input to raw text using RawSanitizer policy.
+// Html entities are unescaped, so result is plain text and
+// must be escaped before embedding in html (see SanitizeText).
 func SanitizeRaw(data string, trim bool) string {
-	clean := bluemonday.StrictPolicy().Sanitize(data)
+	clean := string(SanitizeText(data))
 	if trim {
 		clean = strings.TrimSpace(clean)
 	}
-	return html.UnescapeString(clean)
+	return clean
 }
 
-// SanitizeCommon sanitize input to html with common allowed tags.
+// SanitizeCommon sanitize input to html with common allowed tags using CommonSanitizer policy.
+// Result is html and entities are kept escaped (see SanitizeHTML).
 func SanitizeCommon(data string, trim bool) string {
-	clean := bluemonday.UGCPolicy().Sanitize(data)
+	clean := string(SanitizeHTML(data))
 	if trim {
 		clean = strings.TrimSpace(clean)
 	}
-	return html.UnescapeString(clean)
+	return clean
+}
+
+// Excerpt returns plain text summary of html limited to n characters.
+// Block tags converted to space and text cut on word boundary with ellipsis.
+func Excerpt(data string, n int) string {
+	text := SanitizeRaw(blockTagRx.ReplaceAllString(data, " "), true)
+	text = strings.Join(strings.Fields(text), " ")
+	return Truncate(text, n, TruncateOptions{WordBoundary: true, Ellipsis: "…"})
+}
+
+// NormalizeEmail trim email and normalize domain part with NormalizeDomain.
+// Local part is case sensitive and kept as is.
+func NormalizeEmail(s string) string {
+	email := strings.TrimSpace(s)
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	return email[:at+1] + NormalizeDomain(email[at+1:])
+}
+
+// ValidateEmail validate email address with dot-atom local part (RFC 5322 subset)
+// and internationalized domain. Quoted local parts and ip literals are not supported.
+func ValidateEmail(s string) error {
+	email := NormalizeEmail(s)
+	fail := func(err error) error {
+		return &ValidationError{Kind: "email", Value: s, Err: err}
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return fail(ErrInvalidFormat)
+	}
+	local, domain := email[:at], email[at+1:]
+	if local == "" || len(local) > 64 || len(email) > 254 {
+		return fail(ErrInvalidLength)
+	}
+	if !isEmailLocal(local) {
+		return fail(ErrInvalidFormat)
+	}
+	if err := checkDomain(domain); err != nil {
+		return fail(err)
+	}
+	return nil
+}
+
+// IsEmail check if string is valid email address.
+func IsEmail(s string) bool {
+	return ValidateEmail(s) == nil
+}
+
+// NormalizeURL lowercase scheme and host and convert internationalized host to punycode.
+// Invalid urls returned trimmed.
+func NormalizeURL(s string) string {
+	raw := strings.TrimSpace(s)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if host := u.Hostname(); host != "" {
+		if addr, err := netip.ParseAddr(host); err == nil {
+			host = addr.String()
+		} else {
+			host = NormalizeDomain(host)
+		}
+
+		if port := u.Port(); port != "" {
+			u.Host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			u.Host = "[" + host + "]"
+		} else {
+			u.Host = host
+		}
+	}
+	return u.String()
+}
+
+// ValidateURL validate absolute url with scheme and valid hostname or ip host.
+func ValidateURL(s string) error {
+	fail := func(err error) error {
+		return &ValidationError{Kind: "url", Value: s, Err: err}
+	}
+
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Opaque != "" {
+		return fail(ErrInvalidFormat)
+	}
+	if port := u.Port(); port != "" && !isDigits(port) {
+		return fail(ErrInvalidFormat)
+	}
+
+	host := u.Hostname()
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+	ascii, err := idna.Lookup.ToASCII(strings.TrimRight(host, "."))
+	if err != nil {
+		return fail(ErrInvalidFormat)
+	}
+	if err := checkHostname(ascii); err != nil {
+		return fail(err)
+	}
+	return nil
+}
+
+// IsURL check if string is valid absolute url.
+func IsURL(s string) bool {
+	return ValidateURL(s) == nil
+}
+
+// ValidateRelativeURL validate url without scheme and host.
+// Protocol relative urls (e.g. //example.com or /\example.com) are rejected.
+func ValidateRelativeURL(s string) error {
+	fail := func(err error) error {
+		return &ValidationError{Kind: "relative url", Value: s, Err: err}
+	}
+
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return fail(ErrInvalidLength)
+	}
+	if strings.HasPrefix(raw, "//") || strings.HasPrefix(raw, "/\\") || strings.HasPrefix(raw, "\\\\") {
+		return fail(ErrInvalidPrefix)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return fail(ErrInvalidFormat)
+	}
+	return nil
+}
+
+// IsRelativeURL check if string is valid relative url.
+func IsRelativeURL(s string) bool {
+	return ValidateRelativeURL(s) == nil
+}
+
+// NormalizeHostname trim, lowercase and strip trailing dots of hostname.
+func NormalizeHostname(s string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(s)), ".")
+}
+
+// ValidateHostname validate ascii hostname (RFC 1123).
+// Single label hostnames (e.g. localhost) are valid.
+func ValidateHostname(s string) error {
+	if err := checkHostname(NormalizeHostname(s)); err != nil {
+		return &ValidationError{Kind: "hostname", Value: s, Err: err}
+	}
+	return nil
+}
+
+// IsHostname check if string is valid hostname.
+func IsHostname(s string) bool {
+	return ValidateHostname(s) == nil
+}
+
+// NormalizeDomain trim, lowercase, strip trailing dots and convert
+// internationalized domain to punycode (e.g. "Bücher.Example." to "xn--bcher-kva.example").
+// Invalid domains returned lowercased without conversion.
+func NormalizeDomain(s string) string {
+	domain := NormalizeHostname(s)
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
+
+// ValidateDomain validate domain name with at least two labels and alphabetic top level domain.
+// Internationalized domains are validated in punycode form.
+func ValidateDomain(s string) error {
+	if err := checkDomain(NormalizeHostname(s)); err != nil {
+		return &ValidationError{Kind: "domain", Value: s, Err: err}
+	}
+	return nil
+}
+
+// IsDomain check if string is valid domain name.
+func IsDomain(s string) bool {
+	return ValidateDomain(s) == nil
+}
+
+// NormalizeIP returns canonical form of ip address (e.g. "2001:DB8:0::1" to "2001:db8::1").
+// Invalid addresses returned trimmed.
+func NormalizeIP(s string) string {
+	raw := strings.TrimSpace(s)
+	if addr, err := netip.ParseAddr(raw); err == nil {
+		return addr.String()
+	}
+	return raw
+}
+
+// ValidateIP validate ipv4 or ipv6 address.
+func ValidateIP(s string) error {
+	if _, err := netip.ParseAddr(strings.TrimSpace(s)); err != nil {
+		return &ValidationError{Kind: "ip", Value: s, Err: ErrInvalidFormat}
+	}
+	return nil
+}
+
+// IsIP check if string is valid ip address.
+func IsIP(s string) bool {
+	return ValidateIP(s) == nil
+}
+
+// ValidateIPv4 validate dotted decimal ipv4 address.
+func ValidateIPv4(s string) error {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil || !addr.Is4() {
+		return &ValidationError{Kind: "ipv4", Value: s, Err: ErrInvalidFormat}
+	}
+	return nil
+}
+
+// IsIPv4 check if string is valid ipv4 address.
+func IsIPv4(s string) bool {
+	return ValidateIPv4(s) == nil
+}
+
+// ValidateIPv6 validate ipv6 address.
+func ValidateIPv6(s string) error {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil || !addr.Is6() {
+		return &ValidationError{Kind: "ipv6", Value: s, Err: ErrInvalidFormat}
+	}
+	return nil
+}
+
+// IsIPv6 check if string is valid ipv6 address.
+func IsIPv6(s string) bool {
+	return ValidateIPv6(s) == nil
+}
+
+// NormalizeCIDR returns canonical masked form of cidr (e.g. "192.168.1.10/24" to "192.168.1.0/24").
+// Invalid cidr returned trimmed.
+func NormalizeCIDR(s string) string {
+	raw := strings.TrimSpace(s)
+	if prefix, err := netip.ParsePrefix(raw); err == nil {
+		return prefix.Masked().String()
+	}
+	return raw
+}
+
+// ValidateCIDR validate ipv4 or ipv6 cidr notation.
+func ValidateCIDR(s string) error {
+	if _, err := netip.ParsePrefix(strings.TrimSpace(s)); err != nil {
+		return &ValidationError{Kind: "cidr", Value: s, Err: ErrInvalidFormat}
+	}
+	return nil
+}
+
+// IsCIDR check if string is valid cidr notation.
+func IsCIDR(s string) bool {
+	return ValidateCIDR(s) == nil
+}
+
+// NormalizeHexColor lowercase color, add missing # and expand
+// short forms (e.g. "FA0" to "#ffaa00" and "#fa08" to "#ffaa0088").
+func NormalizeHexColor(s string) string {
+	color := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "#")
+	if len(color) == 3 || len(color) == 4 {
+		var expanded strings.Builder
+		for _, r := range color {
+			expanded.WriteRune(r)
+			expanded.WriteRune(r)
+		}
+		color = expanded.String()
+	}
+	return "#" + color
+}
+
+// ValidateHexColor validate 3, 4, 6 or 8 digits hex color with optional # prefix.
+func ValidateHexColor(s string) error {
+	color := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	fail := func(err error) error {
+		return &ValidationError{Kind: "hex color", Value: s, Err: err}
+	}
+
+	for _, r := range color {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return fail(ErrInvalidFormat)
+		}
+	}
+	switch len(color) {
+	case 3, 4, 6, 8:
+		return nil
+	default:
+		return fail(ErrInvalidLength)
+	}
+}
+
+// IsHexColor check if string is valid hex color.
+func IsHexColor(s string) bool {
+	return ValidateHexColor(s) == nil
+}
+
+// checkDomain returns validation failure reason of domain or nil.
+func checkDomain(domain string) error {
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return ErrInvalidFormat
+	}
+	if err := checkHostname(ascii); err != nil {
+		return err
+	}
+
+	labels := strings.Split(ascii, ".")
+	tld := labels[len(labels)-1]
+	if len(labels) < 2 || len(tld) < 2 || isDigits(tld) {
+		return ErrInvalidFormat
+	}
+	return nil
+}
+
+// checkHostname returns validation failure reason of ascii hostname or nil.
+func checkHostname(host string) error {
+	if host == "" || len(host) > 253 {
+		return ErrInvalidLength
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 {
+			return ErrInvalidLength
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return ErrInvalidFormat
+		}
+		for _, r := range label {
+			if r != '-' && !isAlphaNum(r) {
+				return ErrInvalidFormat
+			}
+		}
+	}
+	return nil
+}
+
+// isEmailLocal check if string is dot-atom email local part.
+// Non-ascii characters allowed for internationalized emails (RFC 6531).
+func isEmailLocal(local string) bool {
+	if !utf8.ValidString(local) || strings.HasPrefix(local, ".") ||
+		strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return false
+	}
+
+	for _, r := range local {
+		if r <= ' ' || r == 0x7f || (r < utf8.RuneSelf && !isAlphaNum(r) && !strings.ContainsRune(".!#$%&'*+-/=?^_`{|}~", r)) {
+			return false
+		}
+	}
+	return true
 }
@@ -1,6 +1,8 @@
 package goutils_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/mekramy/goutils"
@@ -11,16 +13,28 @@ func TestRelativeURL(t *testing.T) {
 		root     string
 		path     []string
 		expected string
+		err      error
 	}{
-		{"g:/mekramy", []string{"g:/mekramy/goutils/web.go"}, "goutils/web.go"},
-		{"g:/mekramy", []string{"g:/mekramy/goutils"}, "goutils"},
-		{"g:/mekramy", []string{"g:/mekramy"}, ""},
+		{"g:/mekramy", []string{"g:/mekramy/goutils/web.go"}, "goutils/web.go", nil},
+		{"g:/mekramy", []string{"g:/mekramy/goutils"}, "goutils", nil},
+		{"g:/mekramy", []string{"g:/mekramy"}, "", nil},
+		{"/", []string{"/var/www"}, "var/www", nil},
+		{"/var/www", []string{"/var/www/", "a/../b.txt"}, "b.txt", nil},
+		{"g:/mekramy", []string{"g:/mekramy2/goutils"}, "", goutils.ErrPathEscapesRoot},
+		{"g:/mekramy", []string{"g:/other/goutils"}, "", goutils.ErrPathEscapesRoot},
+		{"/var/www", []string{"/var/www/../secret"}, "", goutils.ErrPathEscapesRoot},
+		{".", []string{"a.png"}, "a.png", nil},
+		{"", []string{"a.png"}, "a.png", nil},
+		{"", []string{"./assets/a.png"}, "assets/a.png", nil},
+		{".", []string{"."}, "", nil},
+		{".", []string{"../a.png"}, "", goutils.ErrPathEscapesRoot},
+		{"", []string{"/a.png"}, "", goutils.ErrPathEscapesRoot},
 	}
 
 	for _, test := range tests {
-		result := goutils.RelativeURL(test.root, test.path...)
-		if result != test.expected {
-			t.Errorf("RelativeURL(%q, %q) = %q; want %q", test.root, test.path, result, test.expected)
+		result, err := goutils.RelativeURL(test.root, test.path...)
+		if result != test.expected || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("RelativeURL(%q, %q) = %q, %v; want %q, %v", test.root, test.path, result, err, test.expected, test.err)
 		}
 	}
 }
@@ -30,16 +44,18 @@ func TestAbsoluteURL(t *testing.T) {
 		root     string
 		path     []string
 		expected string
+		err      error
 	}{
-		{"g:/mekramy", []string{"g:/mekramy/goutils/web.go"}, "/goutils/web.go"},
-		{"g:/mekramy", []string{"g:/mekramy/goutils"}, "/goutils"},
-		{"g:/mekramy", []string{"g:/mekramy"}, "/"},
+		{"g:/mekramy", []string{"g:/mekramy/goutils/web.go"}, "/goutils/web.go", nil},
+		{"g:/mekramy", []string{"g:/mekramy/goutils"}, "/goutils", nil},
+		{"g:/mekramy", []string{"g:/mekramy"}, "/", nil},
+		{"g:/mekramy", []string{"g:/other"}, "", goutils.ErrPathEscapesRoot},
 	}
 
 	for _, test := range tests {
-		result := goutils.AbsoluteURL(test.root, test.path...)
-		if result != test.expected {
-			t.Errorf("AbsoluteURL(%q, %q) = %q; want %q", test.root, test.path, result, test.expected)
+		result, err := goutils.AbsoluteURL(test.root, test.path...)
+		if result != test.expected || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("AbsoluteURL(%q, %q) = %q, %v; want %q, %v", test.root, test.path, result, err, test.expected, test.err)
 		}
 	}
 }
@@ -81,3 +97,198 @@ func TestSanitizeCommon(t *testing.T) {
 		}
 	}
 }
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		data     string
+		n        int
+		expected string
+	}{
+		{"<p>Hello</p><p>World</p>", 50, "Hello World"},
+		{"<h1>Title</h1><script>alert('xss')</script><p>Some <b>bold</b> text here</p>", 20, "Title Some bold…"},
+		{"<ul><li>one</li><li>two</li></ul>", 7, "one two"},
+	}
+
+	for _, test := range tests {
+		result := goutils.Excerpt(test.data, test.n)
+		if result != test.expected {
+			t.Errorf("Excerpt(%q, %d) = %q; want %q", test.data, test.n, result, test.expected)
+		}
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"john.doe+tag@example.com", nil},
+		{"user@Bücher.Example.", nil},
+		{"کاربر@مثال.ایران", nil},
+		{"@example.com", goutils.ErrInvalidLength},
+		{"john..doe@example.com", goutils.ErrInvalidFormat},
+		{".john@example.com", goutils.ErrInvalidFormat},
+		{"john doe@example.com", goutils.ErrInvalidFormat},
+		{"john@localhost", goutils.ErrInvalidFormat},
+		{"john@-example.com", goutils.ErrInvalidFormat},
+		{"example.com", goutils.ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateEmail(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateEmail(%q) = %v; want %v", test.input, err, test.err)
+		}
+	}
+
+	if result := goutils.NormalizeEmail(" John@Bücher.Example. "); result != "John@xn--bcher-kva.example" {
+		t.Errorf("NormalizeEmail() = %q; want %q", result, "John@xn--bcher-kva.example")
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		absolute bool
+		relative bool
+	}{
+		{"https://example.com/path?q=1#top", true, false},
+		{"http://[2001:db8::1]:8080/", true, false},
+		{"https://مثال.ایران/فایل", true, false},
+		{"ftp://files.example.com", true, false},
+		{"https://exa mple.com", false, false},
+		{"https://example.com:port", false, false},
+		{"mailto:john@example.com", false, false},
+		{"/uploads/file.png?size=large", false, true},
+		{"uploads/file.png", false, true},
+		{"//evil.com/path", false, false},
+		{"/\\evil.com", false, false},
+		{"", false, false},
+	}
+
+	for _, test := range tests {
+		if result := goutils.IsURL(test.input); result != test.absolute {
+			t.Errorf("IsURL(%q) = %v; want %v", test.input, result, test.absolute)
+		}
+		if result := goutils.IsRelativeURL(test.input); result != test.relative {
+			t.Errorf("IsRelativeURL(%q) = %v; want %v", test.input, result, test.relative)
+		}
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"HTTPS://Example.COM./Path", "https://example.com/Path"},
+		{"http://Bücher.example:8080/a?b=c", "http://xn--bcher-kva.example:8080/a?b=c"},
+		{"http://[2001:DB8:0::1]/", "http://[2001:db8::1]/"},
+		{"/relative/Path", "/relative/Path"},
+	}
+
+	for _, test := range tests {
+		if result := goutils.NormalizeURL(test.input); result != test.expected {
+			t.Errorf("NormalizeURL(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestValidateHostnameAndDomain(t *testing.T) {
+	tests := []struct {
+		input    string
+		hostname bool
+		domain   bool
+	}{
+		{"localhost", true, false},
+		{"Example.COM.", true, true},
+		{"my-host.local", true, true},
+		{"xn--bcher-kva.example", true, true},
+		{"bücher.example", false, true},
+		{"example.123", true, false},
+		{"-example.com", false, false},
+		{"exa_mple.com", false, false},
+		{"a..b", false, false},
+		{strings.Repeat("a", 64) + ".com", false, false},
+	}
+
+	for _, test := range tests {
+		if result := goutils.IsHostname(test.input); result != test.hostname {
+			t.Errorf("IsHostname(%q) = %v; want %v", test.input, result, test.hostname)
+		}
+		if result := goutils.IsDomain(test.input); result != test.domain {
+			t.Errorf("IsDomain(%q) = %v; want %v", test.input, result, test.domain)
+		}
+	}
+
+	if result := goutils.NormalizeDomain("Bücher.Example."); result != "xn--bcher-kva.example" {
+		t.Errorf("NormalizeDomain() = %q; want %q", result, "xn--bcher-kva.example")
+	}
+}
+
+func TestValidateIP(t *testing.T) {
+	tests := []struct {
+		input string
+		ipv4  bool
+		ipv6  bool
+		cidr  bool
+	}{
+		{"192.168.1.1", true, false, false},
+		{"2001:db8::1", false, true, false},
+		{"::ffff:192.168.1.1", false, true, false},
+		{"192.168.1.0/24", false, false, true},
+		{"2001:db8::/32", false, false, true},
+		{"256.1.1.1", false, false, false},
+		{"192.168.01.1", false, false, false},
+		{"192.168.1.0/33", false, false, false},
+	}
+
+	for _, test := range tests {
+		if result := goutils.IsIPv4(test.input); result != test.ipv4 {
+			t.Errorf("IsIPv4(%q) = %v; want %v", test.input, result, test.ipv4)
+		}
+		if result := goutils.IsIPv6(test.input); result != test.ipv6 {
+			t.Errorf("IsIPv6(%q) = %v; want %v", test.input, result, test.ipv6)
+		}
+		if result := goutils.IsIP(test.input); result != (test.ipv4 || test.ipv6) {
+			t.Errorf("IsIP(%q) = %v; want %v", test.input, result, test.ipv4 || test.ipv6)
+		}
+		if result := goutils.IsCIDR(test.input); result != test.cidr {
+			t.Errorf("IsCIDR(%q) = %v; want %v", test.input, result, test.cidr)
+		}
+	}
+
+	if result := goutils.NormalizeIP(" 2001:DB8:0:0::1 "); result != "2001:db8::1" {
+		t.Errorf("NormalizeIP() = %q; want %q", result, "2001:db8::1")
+	}
+	if result := goutils.NormalizeCIDR("192.168.1.10/24"); result != "192.168.1.0/24" {
+		t.Errorf("NormalizeCIDR() = %q; want %q", result, "192.168.1.0/24")
+	}
+}
+
+func TestValidateHexColor(t *testing.T) {
+	tests := []struct {
+		input      string
+		err        error
+		normalized string
+	}{
+		{"#FA0", nil, "#ffaa00"},
+		{"fa08", nil, "#ffaa0088"},
+		{"#1A2b3C", nil, "#1a2b3c"},
+		{"#1a2b3c80", nil, "#1a2b3c80"},
+		{"#12345", goutils.ErrInvalidLength, ""},
+		{"#GGG", goutils.ErrInvalidFormat, ""},
+	}
+
+	for _, test := range tests {
+		err := goutils.ValidateHexColor(test.input)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateHexColor(%q) = %v; want %v", test.input, err, test.err)
+		}
+		if test.err == nil {
+			if result := goutils.NormalizeHexColor(test.input); result != test.normalized {
+				t.Errorf("NormalizeHexColor(%q) = %q; want %q", test.input, result, test.normalized)
+			}
+		}
+	}
+}